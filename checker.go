@@ -0,0 +1,152 @@
+package gsandbox
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/souk4711/gsandbox/pkg/fsfilter"
+)
+
+// checker names
+const (
+	CHECKER_EXACT      = "exact"      // byte-by-byte
+	CHECKER_WHITESPACE = "whitespace" // token-by-token, ignores the amount of whitespace
+	CHECKER_FLOAT      = "float"      // token-by-token, numbers are compared with a tolerance
+	CHECKER_CUSTOM     = "custom"     // a checker program
+)
+
+// Checker compares the output of the program with the answer of a judge case
+type Checker interface {
+	Check(c JudgeCase, output []byte) (ok bool, message string, err error)
+}
+
+// ExactChecker accepts the output which is exactly the same as the answer
+type ExactChecker struct{}
+
+func (ExactChecker) Check(c JudgeCase, output []byte) (bool, string, error) {
+	answer, err := os.ReadFile(c.AnswerFile)
+	if err != nil {
+		return false, "", err
+	}
+	if bytes.Equal(output, answer) {
+		return true, "", nil
+	}
+
+	var i = 0
+	for i < len(output) && i < len(answer) && output[i] == answer[i] {
+		i++
+	}
+	return false, fmt.Sprintf("differ at byte %d", i), nil
+}
+
+// WhitespaceChecker accepts the output which has the same tokens as the answer, tokens are separated by whitespace
+type WhitespaceChecker struct{}
+
+func (WhitespaceChecker) Check(c JudgeCase, output []byte) (bool, string, error) {
+	return checkTokens(c, output, func(expected string, found string) bool {
+		return expected == found
+	})
+}
+
+// FloatChecker is a WhitespaceChecker, but the numbers are accepted if the absolute or relative error
+// does not exceed Epsilon
+type FloatChecker struct {
+	Epsilon float64
+}
+
+func (ch FloatChecker) Check(c JudgeCase, output []byte) (bool, string, error) {
+	return checkTokens(c, output, func(expected string, found string) bool {
+		if expected == found {
+			return true
+		}
+		x, err := strconv.ParseFloat(expected, 64)
+		if err != nil {
+			return false
+		}
+		y, err := strconv.ParseFloat(found, 64)
+		if err != nil || math.IsNaN(y) {
+			return false
+		}
+		var diff = math.Abs(x - y)
+		return diff <= ch.Epsilon || diff <= ch.Epsilon*math.Abs(x)
+	})
+}
+
+func checkTokens(c JudgeCase, output []byte, equal func(expected string, found string) bool) (bool, string, error) {
+	answer, err := os.ReadFile(c.AnswerFile)
+	if err != nil {
+		return false, "", err
+	}
+
+	var expected, found = strings.Fields(string(answer)), strings.Fields(string(output))
+	for i := 0; i < len(expected) && i < len(found); i++ {
+		if !equal(expected[i], found[i]) {
+			return false, fmt.Sprintf("token %d differ: expected %q, found %q", i+1, expected[i], found[i]), nil
+		}
+	}
+	if len(expected) != len(found) {
+		return false, fmt.Sprintf("token count differ: expected %d, found %d", len(expected), len(found)), nil
+	}
+	return true, "", nil
+}
+
+// ProgramChecker runs a checker program in the sandbox, like testlib's checkers:
+//
+//	PROG [ARG...] INPUT_FILE OUTPUT_FILE ANSWER_FILE
+//
+// The output is accepted if the checker exits with 0, rejected if exits with 1, the message is read from
+// its stderr. The files are added to the readable files of the policy.
+type ProgramChecker struct {
+	Sandbox *Sandbox
+	Prog    string
+	Args    []string
+}
+
+func (ch ProgramChecker) Check(c JudgeCase, output []byte) (bool, string, error) {
+	dir, err := os.MkdirTemp("", "gsandbox-checker-")
+	if err != nil {
+		return false, "", err
+	}
+	defer os.RemoveAll(dir)
+
+	var outputFile = filepath.Join(dir, "output")
+	if err := os.WriteFile(outputFile, output, 0644); err != nil {
+		return false, "", err
+	}
+
+	var files = make([]string, 0, 3)
+	for _, file := range []string{c.InputFile, outputFile, c.AnswerFile} {
+		if file, err := filepath.Abs(file); err != nil {
+			return false, "", err
+		} else {
+			files = append(files, file)
+		}
+	}
+
+	var executor = ch.Sandbox.NewExecutor(ch.Prog, append(append([]string{}, ch.Args...), files...))
+	var rdFiles = append(append([]string{}, ch.Sandbox.policy.FileSystem.ReadableFiles...), files...)
+	executor.SetFilterFileList(fsfilter.FILE_RD, rdFiles)
+	executor.WithCapturedOutput(1024, 0)
+	executor.Run()
+
+	var r = executor.Result
+	var message string
+	if r.Stderr != nil && r.Stderr.Encoding == OUTPUT_ENCODING_TEXT {
+		message = strings.TrimSpace(r.Stderr.Head)
+	}
+	switch {
+	case r.Status == StatusOK && r.ExitCode == 0:
+		return true, message, nil
+	case r.Status == StatusOK && r.ExitCode == 1:
+		return false, message, nil
+	case r.Status == StatusOK:
+		return false, "", fmt.Errorf("checker: exit with code %d: %s", r.ExitCode, message)
+	default:
+		return false, "", fmt.Errorf("checker: %s: %s", r.Status, r.Reason)
+	}
+}
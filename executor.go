@@ -2,26 +2,37 @@ package gsandbox
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"runtime"
-	"strings"
 	"syscall"
 	"time"
 
-	"github.com/dustin/go-humanize"
 	"github.com/go-logr/logr"
+	"golang.org/x/sys/unix"
 
 	"github.com/souk4711/gsandbox/pkg/fsfilter"
 	"github.com/souk4711/gsandbox/pkg/prlimit"
 	"github.com/souk4711/gsandbox/pkg/ptrace"
 )
 
+// log verbosity levels, plz see Executor#WithLogger
+const (
+	LOG_LEVEL_RESULT  = 0 // start and result of a run, denied syscalls
+	LOG_LEVEL_PROCESS = 1 // rlimits, processes and tracer events
+	LOG_LEVEL_SYSCALL = 2 // syscalls and policy decisions
+	LOG_LEVEL_FD      = 3 // fd tracking of fsfilter
+)
+
 const (
 	// flag names
-	FLAG_SHARE_NETWORK = "share-net"
+	FLAG_SHARE_NETWORK   = "share-net"
+	FLAG_TRUNCATE_OUTPUT = "truncate-output"
 
 	// flag values
 	ENABLED = "enabled"
@@ -61,6 +72,18 @@ type Executor struct {
 	// allowedSyscalls specifies the calls that are allowed
 	allowedSyscalls map[string]struct{}
 
+	// allowedSyscallArgs specifies the calls that are allowed if the args match any group of the filters
+	allowedSyscallArgs map[string][][]SyscallArgFilter
+
+	// allowedCompatSyscalls specifies the calls that are allowed per compat ABI, e.g. i386 on x86-64
+	allowedCompatSyscalls map[string]map[string]struct{}
+
+	// deniedCloneFlags specifies the clone(2)/clone3(2)/unshare(2) flags that are not allowed
+	deniedCloneFlags uint64
+
+	// execRules specifies the argument patterns of execve(2)/execveat(2) per binary
+	execRules map[string][]*regexp.Regexp
+
 	// (rd|wr|ex)Files specifies file acesss rules
 	rdFiles []string
 	wrFiles []string
@@ -72,10 +95,42 @@ type Executor struct {
 	// tracee-related
 	traceePid       int
 	traceeFsFilters map[int]*fsfilter.FsFilter
+	traceeExecDepth map[int]uint64
+	traceeExecCount uint64
+	traceeProcCount uint64 // processes and threads created
+	traceeFileCount uint64 // files opened
+	traceeSyscalls  map[string]*syscallCounter
+	traceeThreads   map[int]int // thread id => thread group id, excluding the thread group leader
+	traceeProcs     map[int]*ProcessResult
+	traceeEnterT    map[int]time.Time
+	traceeExited    bool
+	traceeOOM       error // the first allocation refused by RLIMIT_AS
+	traceeDenied    int   // the file permission denied by the fsfilter, plz see fsfilter.FILE_*
 
 	// logger
 	logger logr.Logger
 
+	// tracer writes the syscall trace, if Executor#WithTrace is called
+	tracer *tracer
+
+	// bufferPreview is the max bytes of a buffer read from the tracee, e.g. the data passed to #write
+	bufferPreview int
+
+	// metrics receives the events, if Executor#WithMetrics is called
+	metrics MetricsHook
+
+	// spanTracer emits the spans, if Executor#WithTracerProvider is called
+	spanTracer SpanTracer
+	spanCtx    context.Context // contains the span of Executor#RunContext
+	ptraceSpan Span            // receives the span events
+
+	// tty is the stdin/stdout/stderr and the controlling terminal, if Executor#WithTTY is called
+	tty *os.File
+
+	// (stdout|stderr)Capture keeps the output, if Executor#WithCapturedOutput is called
+	stdoutCapture *outputCapture
+	stderrCapture *outputCapture
+
 	// sandbox
 	sandbox *Sandbox
 }
@@ -83,17 +138,79 @@ type Executor struct {
 func NewExecutor(prog string, args []string) *Executor {
 	var e = Executor{
 		Prog: prog, Args: args,
-		flags: make(map[string]string), allowedSyscalls: make(map[string]struct{}),
-		traceeFsFilters: make(map[int]*fsfilter.FsFilter),
+		flags: make(map[string]string), allowedSyscalls: make(map[string]struct{}), allowedSyscallArgs: make(map[string][][]SyscallArgFilter),
+		allowedCompatSyscalls: make(map[string]map[string]struct{}), execRules: make(map[string][]*regexp.Regexp),
+		traceeFsFilters: make(map[int]*fsfilter.FsFilter), traceeExecDepth: make(map[int]uint64), traceeThreads: make(map[int]int),
+		traceeProcs: make(map[int]*ProcessResult), traceeEnterT: make(map[int]time.Time), traceeSyscalls: make(map[string]*syscallCounter),
+		bufferPreview: ptrace.DEFAULT_MAX_BUFFER_PREVIEW,
 	}
 	return &e
 }
 
+// WithLogger logs the run with key/value pairs, the verbosity levels are LOG_LEVEL_*
 func (e *Executor) WithLogger(logger logr.Logger) *Executor {
 	e.logger = logger
 	return e
 }
 
+// WithDetailedReport collects syscall, I/O and file statistics into Result.Report
+func (e *Executor) WithDetailedReport() *Executor {
+	e.Result.Report = newReport()
+	return e
+}
+
+// WithTrace writes a syscall trace to w in the specified format, TRACE_FORMAT_JSON or TRACE_FORMAT_STRACE
+func (e *Executor) WithTrace(w io.Writer, format string) *Executor {
+	switch format {
+	case TRACE_FORMAT_JSON, TRACE_FORMAT_STRACE:
+		e.tracer = newTracer(w, format)
+	default:
+		panic("invalid argument to WithTrace")
+	}
+	return e
+}
+
+// WithBufferPreview reads at most n bytes of a buffer passed to a syscall, e.g. the data passed to #write,
+// the default is ptrace.DEFAULT_MAX_BUFFER_PREVIEW
+func (e *Executor) WithBufferPreview(n int) *Executor {
+	if n < 0 {
+		panic("invalid argument to WithBufferPreview")
+	}
+	e.bufferPreview = n
+	return e
+}
+
+// WithMetrics reports the run, the violations and the tracer stops to the hook
+func (e *Executor) WithMetrics(hook MetricsHook) *Executor {
+	e.metrics = hook
+	return e
+}
+
+// WithTracerProvider emits the spans of Executor#Run, plz see SPAN_*
+func (e *Executor) WithTracerProvider(tp TracerProvider) *Executor {
+	e.spanTracer = tp.Tracer(spanTracerName)
+	return e
+}
+
+// WithCapturedOutput captures the first head bytes and the last tail bytes of stdout/stderr into Result,
+// the output is still written to Executor#Stdout and Executor#Stderr
+func (e *Executor) WithCapturedOutput(head int, tail int) *Executor {
+	if head < 0 || tail < 0 {
+		panic("invalid argument to WithCapturedOutput")
+	}
+	e.stdoutCapture = newOutputCapture(head, tail)
+	e.stderrCapture = newOutputCapture(head, tail)
+	return e
+}
+
+// WithTTY runs the command in a new session with the pseudo-terminal as its stdin/stdout/stderr and controlling
+// terminal, Executor#Stdin, Executor#Stdout and Executor#Stderr are ignored. The output limits are still enforced
+// by the tracer, but the output is neither truncated nor captured.
+func (e *Executor) WithTTY(tty *os.File) *Executor {
+	e.tty = tty
+	return e
+}
+
 func (e *Executor) SetFlag(name string, value string) {
 	e.flags[name] = value
 }
@@ -106,6 +223,29 @@ func (e *Executor) AddAllowedSyscall(syscallName string) {
 	e.allowedSyscalls[syscallName] = struct{}{}
 }
 
+// AddAllowedSyscallWithArgFilters allows the call if its args match all of the filters, can be called multiple times
+func (e *Executor) AddAllowedSyscallWithArgFilters(syscallName string, filters []SyscallArgFilter) {
+	e.allowedSyscallArgs[syscallName] = append(e.allowedSyscallArgs[syscallName], filters)
+}
+
+func (e *Executor) AddAllowedCompatSyscall(arch string, syscallName string) {
+	if _, ok := e.allowedCompatSyscalls[arch]; !ok {
+		e.allowedCompatSyscalls[arch] = make(map[string]struct{})
+	}
+	e.allowedCompatSyscalls[arch][syscallName] = struct{}{}
+}
+
+func (e *Executor) AddDeniedCloneFlag(flag uint64) {
+	e.deniedCloneFlags |= flag
+}
+
+// AddExecRule restricts the arguments(excluding argv[0]) of the binary, each one must match any of the patterns.
+// The symlinks are resolved, so the rule of /usr/bin/git also applies to /bin/git on a merged-/usr system.
+func (e *Executor) AddExecRule(path string, args []*regexp.Regexp) {
+	path = resolveExecPath(path)
+	e.execRules[path] = append(e.execRules[path], args...)
+}
+
 func (e *Executor) SetFilterFileList(perm int, files []string) {
 	switch perm {
 	case fsfilter.FILE_RD:
@@ -120,49 +260,90 @@ func (e *Executor) SetFilterFileList(perm int, files []string) {
 }
 
 func (e *Executor) Run() {
+	e.RunContext(context.Background())
+}
+
+// RunContext is Executor#Run, the spans are the children of the span in ctx, if any, and the program is killed
+// once ctx is done
+func (e *Executor) RunContext(ctx context.Context) {
 	// Because the go runtime forks traced processes with PTRACE_TRACEME
 	// we need to maintain the parent-child relationship for ptrace to work.
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
 
+	// metrics
+	if e.metrics != nil {
+		e.metrics.ExecutorStarted()
+		defer func() {
+			e.metrics.ExecutorFinished(&e.Result)
+		}()
+	}
+
+	// spans
+	var span Span
+	e.spanCtx, span = e.startSpan(ctx, SPAN_RUN, SpanAttribute{Key: "prog", Value: e.Prog})
+	defer func() {
+		var r = &e.Result
+		span.SetAttributes(
+			SpanAttribute{Key: "status", Value: r.Status.String()},
+			SpanAttribute{Key: "exitCode", Value: r.ExitCode},
+			SpanAttribute{Key: "processes", Value: len(r.Processes)},
+		)
+		if r.Status == StatusSandboxFailure || r.Status == StatusExitFailure {
+			span.RecordError(errors.New(r.Reason))
+		}
+		span.End()
+	}()
+
 	// timeout
 	var cmd *exec.Cmd
 	if lim := e.limits.LimitWallClockTime; lim != nil {
-		e.info(fmt.Sprintf("proc: StartWithTimeout(%s): %s %s", time.Duration(*lim*uint64(time.Second)), e.Prog, strings.Join(e.Args, " ")))
-		var ctx, cancel = context.WithTimeout(context.Background(), time.Duration(*lim)*time.Second)
+		e.log(LOG_LEVEL_RESULT, "proc: Start", "prog", e.Prog, "args", e.Args, "timeout", time.Duration(*lim)*time.Second)
+		var cmdCtx, cancel = context.WithTimeout(ctx, time.Duration(*lim)*time.Second)
 		defer cancel()
-		cmd = exec.CommandContext(ctx, e.Prog, e.Args...)
+		cmd = exec.CommandContext(cmdCtx, e.Prog, e.Args...)
 	} else {
-		e.info(fmt.Sprintf("proc: Start: %s %s", e.Prog, strings.Join(e.Args, " ")))
-		cmd = exec.Command(e.Prog, e.Args...)
+		e.log(LOG_LEVEL_RESULT, "proc: Start", "prog", e.Prog, "args", e.Args)
+		cmd = exec.CommandContext(ctx, e.Prog, e.Args...)
 	}
 
 	// env, stdin, stdout, stderr
 	cmd.Env = e.Env
 	cmd.Dir = e.Dir
-	cmd.Stdin = e.Stdin
-	cmd.Stdout = e.Stdout
-	cmd.Stderr = e.Stderr
+	if e.tty != nil {
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = e.tty, e.tty, e.tty
+	} else {
+		cmd.Stdin = e.Stdin
+		cmd.Stdout = teeOutput(newOutputWriter(e.Stdout, e.limits.LimitStdout), e.stdoutCapture)
+		cmd.Stderr = teeOutput(newOutputWriter(e.Stderr, e.limits.LimitStderr), e.stderrCapture)
+	}
 
 	// proc-attr
 	e.cmd = cmd
+	var _, setupSpan = e.startSpan(e.spanCtx, SPAN_SETUP_NAMESPACES)
 	e.setCmdProcAttr()
+	setupSpan.End()
 
 	// run
 	e.run()
+	if e.Result.Report != nil {
+		e.Result.Report.finish()
+	}
+	if e.tracer != nil {
+		e.tracer.finish()
+	}
+	if e.stdoutCapture != nil && e.tty == nil {
+		e.Result.Stdout = e.stdoutCapture.result()
+		e.Result.Stderr = e.stderrCapture.result()
+	}
 
 	// logging
 	r := &e.Result
-	e.info("proc: Finished:")
-	e.info(fmt.Sprintf("        status: %d, %s", r.Status, r.Status))
-	e.info(fmt.Sprintf("        reason: %s", r.Reason))
-	e.info(fmt.Sprintf("      exitCode: %d", r.ExitCode))
-	e.info(fmt.Sprintf("        startT: %s", r.StartTime.Format(time.ANSIC)))
-	e.info(fmt.Sprintf("       finishT: %s", r.FinishTime.Format(time.ANSIC)))
-	e.info(fmt.Sprintf("          real: %s", r.RealTime))
-	e.info(fmt.Sprintf("           sys: %s", r.SystemTime))
-	e.info(fmt.Sprintf("          user: %s", r.UserTime))
-	e.info(fmt.Sprintf("           rss: %s", humanize.IBytes(uint64(r.Maxrss))))
+	e.log(LOG_LEVEL_RESULT, "proc: Finished",
+		"status", r.Status, "reason", r.Reason, "exitCode", r.ExitCode,
+		"startTime", r.StartTime, "finishTime", r.FinishTime,
+		"realTime", r.RealTime, "systemTime", r.SystemTime, "userTime", r.UserTime, "maxrss", r.Maxrss,
+		"stdoutBytes", r.StdoutBytes, "stderrBytes", r.StderrBytes, "processes", len(r.Processes))
 }
 
 func (e *Executor) setCmdProcAttr() {
@@ -191,15 +372,32 @@ func (e *Executor) setCmdProcAttr() {
 		Ptrace:       true, // required by ptrace
 		Setpgid:      true, // required by ptrace
 	}
+	if e.tty != nil { // a new session is also a new process group
+		e.cmd.SysProcAttr.Setpgid = false
+		e.cmd.SysProcAttr.Setsid = true
+		e.cmd.SysProcAttr.Setctty = true
+		e.cmd.SysProcAttr.Ctty = 0 // stdin in the child
+	}
 }
 
 func (e *Executor) run() {
 	// start a new process
 	e.Result.StartTime = time.Now()
+	var _, execSpan = e.startSpan(e.spanCtx, SPAN_EXEC)
 	if err := e.cmd.Start(); err != nil {
+		execSpan.RecordError(err)
+		execSpan.End()
 		e.setResultWithExecFailure(err)
 		return
 	}
+	execSpan.SetAttributes(SpanAttribute{Key: "pid", Value: e.cmd.Process.Pid})
+	execSpan.End()
+
+	// teardown, the deferred calls below run in reverse order
+	var teardownSpan Span = noopSpan{}
+	defer func() {
+		teardownSpan.End()
+	}()
 
 	// cleanup
 	if e.sandbox != nil {
@@ -208,25 +406,67 @@ func (e *Executor) run() {
 			e.sandbox.removeRunningExecutor(e)
 		}()
 	}
+	defer func() { // wait for copying stdin/stdout/stderr if they are not *os.File
+		var done = make(chan struct{})
+		go func() {
+			_ = e.cmd.Wait() // the process is already reaped, only the copying goroutines are waited
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second): // the pipe is still held by an untraced process
+		}
+	}()
 	defer func() { // avoid child process become a zombie process
-		_, _ = syscall.Wait4(-e.cmd.Process.Pid, nil, syscall.WALL, nil)
+		for {
+			var ws syscall.WaitStatus
+			wpid, err := syscall.Wait4(-1, &ws, syscall.WALL|unix.WNOTHREAD, nil) // the tracees of this thread
+			if err != nil {
+				return
+			}
+			if ws.Stopped() { // the tracer returns early, the killed tracee stops at PTRACE_EVENT_EXIT
+				_ = syscall.PtraceCont(wpid, 0)
+			}
+		}
+	}()
+	defer func() {
+		_, teardownSpan = e.startSpan(e.spanCtx, SPAN_TEARDOWN)
 	}()
 
 	// set child process resource limit
 	var pid = e.cmd.Process.Pid
+	e.traceeProcs[pid] = &ProcessResult{Pid: pid, Ppid: os.Getpid(), Argv: append([]string{e.Prog}, e.Args...), StartTime: e.Result.StartTime}
+	var _, rlimitsSpan = e.startSpan(e.spanCtx, SPAN_SET_RLIMITS)
 	if err := e.setCmdRlimits(pid); err != nil {
+		rlimitsSpan.RecordError(err)
+		rlimitsSpan.End()
 		e.setResultWithSandboxFailure(err)
 		return
 	}
+	rlimitsSpan.End()
 
 	// set fsfilter
+	var _, fsfilterSpan = e.startSpan(e.spanCtx, SPAN_BUILD_FSFILTER)
 	if err := e.setFsFilter(pid); err != nil {
+		fsfilterSpan.RecordError(err)
+		fsfilterSpan.End()
 		e.setResultWithSandboxFailure(err)
 		return
 	}
+	fsfilterSpan.End()
 
 	// start ptrace
+	_, e.ptraceSpan = e.startSpan(e.spanCtx, SPAN_PTRACE_LOOP)
 	ptrace.Trace(pid, e)
+	e.ptraceSpan.End()
+}
+
+// startSpan starts a span if Executor#WithTracerProvider is called, returns a ctx containing the span
+func (e *Executor) startSpan(ctx context.Context, name string, attrs ...SpanAttribute) (context.Context, Span) {
+	if e.spanTracer == nil {
+		return ctx, noopSpan{}
+	}
+	return e.spanTracer.Start(ctx, name, attrs...)
 }
 
 func (e *Executor) setCmdRlimits(pid int) error {
@@ -236,7 +476,7 @@ func (e *Executor) setCmdRlimits(pid int) error {
 	}()
 
 	if lim := e.limits.RlimitAS; lim != nil {
-		e.info(fmt.Sprintf("setrlimit:       as => %s", humanize.IBytes(*lim)))
+		e.log(LOG_LEVEL_PROCESS, "setrlimit", "resource", "as", "value", *lim)
 		var rlim = syscall.Rlimit{Cur: *lim, Max: *lim}
 		if err := prlimit.Setprlimit(pid, syscall.RLIMIT_AS, &rlim); err != nil {
 			return fmt.Errorf("setrlimit: SetAS: %s", err.Error())
@@ -244,7 +484,7 @@ func (e *Executor) setCmdRlimits(pid int) error {
 	}
 
 	if lim := e.limits.RlimitCPU; lim != nil {
-		e.info(fmt.Sprintf("setrlimit:      cpu => %s", time.Duration(*lim*uint64(time.Second))))
+		e.log(LOG_LEVEL_PROCESS, "setrlimit", "resource", "cpu", "value", *lim)
 		var rlim = syscall.Rlimit{Cur: *lim, Max: *lim}
 		if err := prlimit.Setprlimit(pid, syscall.RLIMIT_CPU, &rlim); err != nil {
 			return fmt.Errorf("setrlimit: SetCPU: %s", err.Error())
@@ -252,7 +492,7 @@ func (e *Executor) setCmdRlimits(pid int) error {
 	}
 
 	if lim := e.limits.RlimitCORE; lim != nil {
-		e.info(fmt.Sprintf("setrlimit:     core => %s", humanize.IBytes(*lim)))
+		e.log(LOG_LEVEL_PROCESS, "setrlimit", "resource", "core", "value", *lim)
 		var rlim = syscall.Rlimit{Cur: *lim, Max: *lim}
 		if err := prlimit.Setprlimit(pid, syscall.RLIMIT_CORE, &rlim); err != nil {
 			return fmt.Errorf("setrlimit: SetCORE: %s", err.Error())
@@ -260,7 +500,7 @@ func (e *Executor) setCmdRlimits(pid int) error {
 	}
 
 	if lim := e.limits.RlimitFSIZE; lim != nil {
-		e.info(fmt.Sprintf("setrlimit:    fsize => %s", humanize.IBytes(*lim)))
+		e.log(LOG_LEVEL_PROCESS, "setrlimit", "resource", "fsize", "value", *lim)
 		var rlim = syscall.Rlimit{Cur: *lim, Max: *lim}
 		if err := prlimit.Setprlimit(pid, syscall.RLIMIT_FSIZE, &rlim); err != nil {
 			return fmt.Errorf("setrlimit: SetFSIZE: %s", err.Error())
@@ -268,7 +508,7 @@ func (e *Executor) setCmdRlimits(pid int) error {
 	}
 
 	if lim := e.limits.RlimitNOFILE; lim != nil {
-		e.info(fmt.Sprintf("setrlimit:   nofile => %d", *lim))
+		e.log(LOG_LEVEL_PROCESS, "setrlimit", "resource", "nofile", "value", *lim)
 		var rlim = syscall.Rlimit{Cur: *lim, Max: *lim}
 		if err := prlimit.Setprlimit(pid, syscall.RLIMIT_NOFILE, &rlim); err != nil {
 			return fmt.Errorf("setrlimit: SetNOFILE: %s", err.Error())
@@ -296,6 +536,11 @@ func (e *Executor) setFsFilter(pid int) error {
 		}
 	}
 
+	if e.tty != nil {
+		filter.AddTTY(e.tty.Name())
+		filter.AddTTY("/dev/tty")
+	}
+
 	e.traceeFsFilters[pid] = filter
 	return nil
 }
@@ -338,6 +583,45 @@ func (e *Executor) setResult(ws *syscall.WaitStatus, rusage *syscall.Rusage) {
 	}
 }
 
+// addProcessResult records the exit info of a process, the user/system time and rss of the result
+// are aggregated for the whole process tree.
+//
+// The rusage of a process includes its descendants it reaped, and orphans are reaped by the
+// command (the init process of the pid namespace), so only the processes outlive the command are added.
+func (e *Executor) addProcessResult(pid int, ws syscall.WaitStatus, rusage syscall.Rusage) {
+	if _, ok := e.traceeThreads[pid]; ok {
+		delete(e.traceeThreads, pid)
+		return
+	}
+
+	var p, ok = e.traceeProcs[pid]
+	if !ok {
+		p = &ProcessResult{Pid: pid}
+	}
+	delete(e.traceeProcs, pid)
+
+	p.FinishTime = time.Now()
+	p.SystemTime = time.Duration(rusage.Stime.Nano()) * time.Nanosecond
+	p.UserTime = time.Duration(rusage.Utime.Nano()) * time.Nanosecond
+	p.Maxrss = rusage.Maxrss
+	if ws.Signaled() {
+		p.Signal = ws.Signal().String()
+		p.ExitCode = int(ws.Signal())
+	} else {
+		p.ExitCode = ws.ExitStatus()
+	}
+
+	r := &e.Result
+	r.Processes = append(r.Processes, *p)
+	if e.traceeExited && pid != e.cmd.Process.Pid {
+		r.SystemTime += p.SystemTime
+		r.UserTime += p.UserTime
+		if p.Maxrss > r.Maxrss {
+			r.Maxrss = p.Maxrss
+		}
+	}
+}
+
 func (e *Executor) setResultWithOK(ws *syscall.WaitStatus, rusage *syscall.Rusage) {
 	r := &e.Result
 	r.FinishTime = time.Now()
@@ -348,6 +632,7 @@ func (e *Executor) setResultWithOK(ws *syscall.WaitStatus, rusage *syscall.Rusag
 
 func (e *Executor) setResultWithSandboxFailure(err error) {
 	r := &e.Result
+	e.logger.Error(err, "proc: SandboxFailure", "pid", e.traceePid)
 	r.FinishTime = time.Now()
 	r.Status = StatusSandboxFailure
 	r.Reason = err.Error()
@@ -364,6 +649,30 @@ func (e *Executor) setResultWithViolation(err error) {
 	_ = syscall.Kill(-e.cmd.Process.Pid, syscall.SIGKILL) // ensure child process will not block the parent process
 }
 
+// setResultWithFsViolation is #setResultWithViolation, perm is the file permission denied, plz see fsfilter.FILE_*
+func (e *Executor) setResultWithFsViolation(err error, perm int) {
+	e.traceeDenied = perm
+	e.setResultWithViolation(err)
+}
+
+func (e *Executor) setResultWithLimitExceeded(err error) {
+	r := &e.Result
+	r.FinishTime = time.Now()
+	r.Status = StatusSyscallLimitExceeded
+	r.Reason = err.Error()
+	e.setResult(nil, nil)
+	_ = syscall.Kill(-e.cmd.Process.Pid, syscall.SIGKILL) // ensure child process will not block the parent process
+}
+
+func (e *Executor) setResultWithOutputLimitExceeded(err error) {
+	r := &e.Result
+	r.FinishTime = time.Now()
+	r.Status = StatusOutputLimitExceeded
+	r.Reason = err.Error()
+	e.setResult(nil, nil)
+	_ = syscall.Kill(-e.cmd.Process.Pid, syscall.SIGKILL) // ensure child process will not block the parent process
+}
+
 func (e *Executor) setResultWithExecFailure(err error) {
 	r := &e.Result
 	r.FinishTime = time.Now()
@@ -372,10 +681,30 @@ func (e *Executor) setResultWithExecFailure(err error) {
 	e.setResult(nil, nil)
 }
 
-func (e *Executor) info(msg string) {
-	e.infoWithPid(msg, e.traceePid)
+// log logs the message with the key/value pairs and the pid of the current tracee, if the level is enabled
+func (e *Executor) log(level int, msg string, keysAndValues ...interface{}) {
+	if e.traceePid == 0 {
+		e.logger.V(level).Info(msg, keysAndValues...)
+		return
+	}
+	e.logWithPid(level, e.traceePid, msg, keysAndValues...)
+}
+
+func (e *Executor) logWithPid(level int, pid int, msg string, keysAndValues ...interface{}) {
+	if logger := e.logger.V(level); logger.Enabled() {
+		logger.Info(msg, append([]interface{}{"pid", pid}, keysAndValues...)...)
+	}
 }
 
-func (e *Executor) infoWithPid(msg string, pid int) {
-	e.logger.Info(fmt.Sprintf("[%d] %s", pid, msg))
+// logEnabled reports whether the level is enabled, used to avoid building the values of a disabled level
+func (e *Executor) logEnabled(level int) bool {
+	return e.logger.V(level).Enabled()
+}
+
+// resolveExecPath resolves the symlinks of the path, or cleans the path if it can't be resolved
+func resolveExecPath(path string) string {
+	if v, err := filepath.EvalSymlinks(path); err == nil {
+		return v
+	}
+	return filepath.Clean(path)
 }
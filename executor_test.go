@@ -0,0 +1,141 @@
+package gsandbox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// the policy used by the tests, allows running the programs built by buildTestProg
+const testPolicyData = `
+syscalls: ["@system-service", prlimit64]
+fs:
+  rd-files: [/usr/, /lib/, /lib64/, /etc/, /proc/, /dev/null, %[1]s/]
+  wr-files: [/dev/null]
+  ex-files: [/usr/, /lib/, /lib64/, %[1]s/]
+`
+
+// buildTestProg builds testdata/NAME.c with cc, the test is skipped if no cc is available
+func buildTestProg(t *testing.T, name string) string {
+	t.Helper()
+
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("cc not found")
+	}
+
+	var prog = filepath.Join(t.TempDir(), name)
+	if out, err := exec.Command(cc, "-pthread", "-o", prog, filepath.Join("testdata", name+".c")).CombinedOutput(); err != nil {
+		t.Fatalf("cc: %s: %s", err.Error(), out)
+	}
+	return prog
+}
+
+// newTestSandbox returns a sandbox with the policy allowing prog
+func newTestSandbox(t *testing.T, prog string) *Sandbox {
+	t.Helper()
+
+	var sandbox = NewSandbox()
+	if err := sandbox.LoadPolicyFromData([]byte(fmt.Sprintf(testPolicyData, filepath.Dir(prog)))); err != nil {
+		t.Fatal(err)
+	}
+	return sandbox
+}
+
+// runTestProg builds and runs testdata/NAME.c in a sandbox
+func runTestProg(t *testing.T, name string, args ...string) *Result {
+	t.Helper()
+
+	var prog = buildTestProg(t, name)
+	var executor = newTestSandbox(t, prog).NewExecutor(prog, args)
+	executor.Run()
+	return &executor.Result
+}
+
+func TestExecutorFdTableSharedByThreads(t *testing.T) {
+	var r = runTestProg(t, "fdtable_pthread")
+	if r.Status != StatusOK || r.ExitCode != 0 {
+		t.Fatalf("status: %s, reason: %s, exitCode: %d", r.Status, r.Reason, r.ExitCode)
+	}
+}
+
+func TestExecutorFdTableCopiedByVfork(t *testing.T) {
+	var r = runTestProg(t, "fdtable_vfork")
+	if r.Status != StatusOK || r.ExitCode != 0 {
+		t.Fatalf("status: %s, reason: %s, exitCode: %d", r.Status, r.Reason, r.ExitCode)
+	}
+	if len(r.Processes) != 2 {
+		t.Fatalf("processes: %d, want 2", len(r.Processes))
+	}
+}
+
+func TestExecutorFdTableUnsharedByExec(t *testing.T) {
+	var r = runTestProg(t, "fdtable_clone_exec")
+	if r.Status != StatusOK || r.ExitCode != 0 {
+		t.Fatalf("status: %s, reason: %s, exitCode: %d", r.Status, r.Reason, r.ExitCode)
+	}
+	if len(r.Processes) != 2 {
+		t.Fatalf("processes: %d, want 2", len(r.Processes))
+	}
+}
+
+func TestExecutorExecDepthLimitExceeded(t *testing.T) {
+	var prog = "/usr/bin/env"
+	if _, err := os.Stat(prog); err != nil {
+		t.Skip("env not found")
+	}
+	var lim uint64 = 0
+
+	var executor = newTestSandbox(t, prog).NewExecutor(prog, []string{"/usr/bin/true"})
+	executor.limits.LimitExecDepth = &lim
+	executor.Run()
+	if r := executor.Result; r.Status != StatusSyscallLimitExceeded {
+		t.Fatalf("status: %s, reason: %s, exitCode: %d", r.Status, r.Reason, r.ExitCode)
+	}
+}
+
+func TestExecutorSignaledByFault(t *testing.T) {
+	var r = runTestProg(t, "segv")
+	if r.Status != StatusSignaled || r.ExitCode != int(syscall.SIGSEGV) {
+		t.Fatalf("status: %s, reason: %s, exitCode: %d", r.Status, r.Reason, r.ExitCode)
+	}
+}
+
+func TestExecutorMemoryLimitExceeded(t *testing.T) {
+	var prog = buildTestProg(t, "memory_limit")
+	var lim uint64 = 64 << 20
+
+	var executor = newTestSandbox(t, prog).NewExecutor(prog, nil)
+	executor.limits.RlimitAS = &lim
+	executor.Run()
+	if r := executor.Result; r.Status != StatusMemoryLimitExceeded {
+		t.Fatalf("status: %s, reason: %s, exitCode: %d", r.Status, r.Reason, r.ExitCode)
+	}
+
+	executor = newTestSandbox(t, prog).NewExecutor(prog, []string{"recover"})
+	executor.limits.RlimitAS = &lim
+	executor.Run()
+	if r := executor.Result; r.Status != StatusOK || r.ExitCode != 0 {
+		t.Fatalf("status: %s, reason: %s, exitCode: %d", r.Status, r.Reason, r.ExitCode)
+	}
+}
+
+func TestExecutorRunContextCanceled(t *testing.T) {
+	var prog = "/usr/bin/sleep"
+	if _, err := os.Stat(prog); err != nil {
+		t.Skip("sleep not found")
+	}
+	var executor = newTestSandbox(t, prog).NewExecutor(prog, []string{"10"})
+
+	var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	executor.RunContext(ctx)
+	if r := executor.Result; r.Status != StatusTimeLimitExceeded || r.RealTime > 5*time.Second {
+		t.Fatalf("status: %s, reason: %s, realTime: %s", r.Status, r.Reason, r.RealTime)
+	}
+}
@@ -1,10 +1,13 @@
 package gsandbox
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"strings"
 	"syscall"
+	"time"
 
 	"golang.org/x/sys/unix"
 
@@ -12,8 +15,13 @@ import (
 	"github.com/souk4711/gsandbox/pkg/ptrace"
 )
 
+const (
+	// BINPRM_MAX_RECURSION, the kernel refuses to exec a script whose interpreter chain is deeper
+	maxInterpreterDepth = 4
+)
+
 func (e *Executor) HandleTracerLogging(pid int, msg string) {
-	e.infoWithPid("tracer: "+msg, pid)
+	e.logWithPid(LOG_LEVEL_PROCESS, pid, "tracer: "+msg)
 }
 
 func (e *Executor) HandleTracerPanicEvent(err error) {
@@ -21,21 +29,114 @@ func (e *Executor) HandleTracerPanicEvent(err error) {
 }
 
 func (e *Executor) HandleTracerExitedEvent(pid int, ws syscall.WaitStatus, rusage syscall.Rusage) {
+	if e.metrics != nil {
+		e.metrics.TracerStop(TRACER_STOP_EXIT)
+	}
+
 	if pid == e.cmd.Process.Pid {
 		e.setResultWithOK(&ws, &rusage)
+		e.handleTracerRootExited()
 	}
+	e.addProcessResult(pid, ws, rusage)
+	e.untrackTracee(pid)
 }
 
 func (e *Executor) HandleTracerSignaledEvent(pid int, ws syscall.WaitStatus, rusage syscall.Rusage) {
+	if e.metrics != nil {
+		e.metrics.TracerStop(TRACER_STOP_EXIT)
+	}
+
 	if pid == e.cmd.Process.Pid {
 		e.setResult(&ws, &rusage)
+		e.handleTracerRootExited()
+	}
+	e.addProcessResult(pid, ws, rusage)
+	e.untrackTracee(pid)
+}
+
+func (e *Executor) handleTracerRootExited() {
+	e.traceeExited = true
+
+	// fails after an allocation is refused, e.g. a SIGSEGV of the NULL returned by malloc(3), an uncaught std::bad_alloc
+	var r = &e.Result
+	if e.traceeOOM != nil && (r.Status == StatusSignaled || r.Status == StatusOK && r.ExitCode != 0) {
+		r.Status = StatusMemoryLimitExceeded
+		r.Reason = e.traceeOOM.Error()
+	}
+}
+
+func (e *Executor) untrackTracee(pid int) {
+	delete(e.traceeFsFilters, pid)
+	delete(e.traceeExecDepth, pid)
+	delete(e.traceeEnterT, pid)
+	if e.tracer != nil {
+		e.tracer.flush(pid)
 	}
 }
 
-func (e *Executor) HandleTracerNewChildEvent(pid int, childPid int) {
+func (e *Executor) HandleTracerNewChildEvent(pid int, childPid int, cloneFlags uint64) {
+	if e.metrics != nil {
+		e.metrics.TracerStop(TRACER_STOP_NEW_CHILD)
+	}
+	e.ptraceSpan.AddEvent(SPAN_EVENT_NEW_CHILD,
+		SpanAttribute{Key: "pid", Value: pid},
+		SpanAttribute{Key: "childPid", Value: childPid},
+		SpanAttribute{Key: "thread", Value: cloneFlags&unix.CLONE_THREAD != 0},
+	)
+
 	parentFsFilter := e.traceeFsFilters[pid]
-	childFsFilter := fsfilter.NewFsFilterInheritFromParent(childPid, parentFsFilter)
+	var childFsFilter *fsfilter.FsFilter
+	if cloneFlags&unix.CLONE_FILES != 0 {
+		childFsFilter = fsfilter.NewFsFilterShareWithParent(childPid, parentFsFilter)
+	} else {
+		childFsFilter = fsfilter.NewFsFilterInheritFromParent(childPid, parentFsFilter)
+	}
 	e.traceeFsFilters[childPid] = childFsFilter
+	e.traceeExecDepth[childPid] = e.traceeExecDepth[pid]
+	e.traceeProcCount += 1
+
+	// process tree
+	var tgid = pid
+	if v, ok := e.traceeThreads[pid]; ok {
+		tgid = v
+	}
+	if cloneFlags&unix.CLONE_THREAD != 0 {
+		e.traceeThreads[childPid] = tgid
+		return
+	}
+
+	var parent = e.traceeProcs[tgid]
+	var child = &ProcessResult{Pid: childPid, Ppid: tgid, StartTime: time.Now()}
+	if parent != nil {
+		child.Argv = parent.Argv
+		if cloneFlags&unix.CLONE_PARENT != 0 {
+			child.Ppid = parent.Ppid
+		}
+	}
+	e.traceeProcs[childPid] = child
+	if e.Result.Report != nil {
+		e.Result.Report.Forks += 1
+	}
+}
+
+func (e *Executor) HandleTracerExecEvent(pid int, formerPid int) {
+	if e.metrics != nil {
+		e.metrics.TracerStop(TRACER_STOP_EXEC)
+	}
+
+	if pid == formerPid {
+		return
+	}
+
+	// a non-leader thread execs, it takes over the thread group leader's pid
+	e.traceeFsFilters[pid] = fsfilter.NewFsFilterShareWithParent(pid, e.traceeFsFilters[formerPid])
+	e.traceeExecDepth[pid] = e.traceeExecDepth[formerPid]
+	delete(e.traceeFsFilters, formerPid)
+	delete(e.traceeExecDepth, formerPid)
+	delete(e.traceeThreads, formerPid)
+	if e.tracer != nil {
+		e.tracer.exec(pid, formerPid)
+	}
 }
 
 func (e *Executor) HandleTracerSyscallEnterEvent(pid int, curr *ptrace.Syscall) (continued bool) {
@@ -44,38 +145,211 @@ func (e *Executor) HandleTracerSyscallEnterEvent(pid int, curr *ptrace.Syscall)
 		e.traceePid = 0
 	}()
 
+	// logging, metrics and span events, after the policy decision is made
+	if e.metrics != nil {
+		e.metrics.TracerStop(TRACER_STOP_SYSCALL_ENTER)
+	}
+	defer func() {
+		if continued || (e.Result.Status != StatusViolation && e.Result.Status != StatusSyscallLimitExceeded) {
+			return
+		}
+		e.log(LOG_LEVEL_RESULT, "syscall: Enter", "syscall", curr.GetName(), "decision", TRACE_DECISION_DENY, "reason", e.Result.Reason)
+		if e.Result.Status != StatusViolation {
+			return
+		}
+		if e.metrics != nil {
+			e.metrics.Violation(curr.GetName(), fsPermMetricsLabel(e.traceeDenied))
+		}
+		e.ptraceSpan.AddEvent(SPAN_EVENT_VIOLATION,
+			SpanAttribute{Key: "pid", Value: pid},
+			SpanAttribute{Key: "syscall", Value: curr.GetName()},
+			SpanAttribute{Key: "reason", Value: e.Result.Reason},
+		)
+	}()
+
 	// prepare data from regs
-	if err := curr.ReadArgs(); err != nil {
+	curr.SetMaxBufferPreview(e.bufferPreview)
+	if err := curr.ReadArgs(); errors.Is(err, ptrace.ErrTooLong) { // e.g. an argument of execve(2) exceeds MAX_ARG_STRLEN
+		err = fmt.Errorf("syscall: IllegalArgs: func(%s), %s", curr.GetName(), err.Error())
+		e.setResultWithViolation(err)
+		return false
+	} else if err != nil {
 		err = fmt.Errorf("ptrace: %s", err.Error())
 		e.setResultWithSandboxFailure(err)
 		return false
 	}
 
+	// trace, after the policy decision is made
+	if e.tracer != nil {
+		defer func() {
+			e.tracer.enter(pid, curr, continued, e.Result.Reason)
+		}()
+	}
+
 	// logging
-	var name = curr.GetName()
-	var args = make([]string, len(curr.GetArgs()))
-	for i, arg := range curr.GetArgs() {
-		args[i] = arg.String()
+	if e.logEnabled(LOG_LEVEL_SYSCALL) {
+		var args = make([]string, len(curr.GetArgs()))
+		for i, arg := range curr.GetArgs() {
+			args[i] = arg.String()
+		}
+		if curr.IsCompat() {
+			e.log(LOG_LEVEL_SYSCALL, "syscall: Enter", "syscall", curr.GetName(), "arch", curr.GetArch(), "args", args)
+		} else {
+			e.log(LOG_LEVEL_SYSCALL, "syscall: Enter", "syscall", curr.GetName(), "args", args)
+		}
+	}
+
+	// report
+	if r := e.Result.Report; r != nil {
+		r.addSyscallCall(curr.GetName())
+		e.traceeEnterT[pid] = time.Now()
 	}
-	e.info(fmt.Sprintf("syscall: Enter: %s(%s)", name, strings.Join(args, ", ")))
 
 	// filter - restrict syscall access
 	if continued := e.HandleTracerSyscallEnterEvent_CheckSyscallAccess(pid, curr); !continued {
 		return false
 	}
 
+	// filter - restrict syscall count and rate
+	if continued := e.HandleTracerSyscallEnterEvent_CheckLimits(pid, curr); !continued {
+		return false
+	}
+
+	// filter - restrict clone flags
+	if continued := e.HandleTracerSyscallEnterEvent_CheckCloneFlags(pid, curr); !continued {
+		return false
+	}
+
 	// filter - restrict file access
 	if continued := e.HandleTracerSyscallEnterEvent_CheckFileAccess(pid, curr); !continued {
 		return false
 	}
 
+	// filter - restrict exec
+	if continued := e.HandleTracerSyscallEnterEvent_CheckExec(pid, curr); !continued {
+		return false
+	}
+
 	// ok
 	return true
 }
 
 func (e *Executor) HandleTracerSyscallEnterEvent_CheckSyscallAccess(pid int, curr *ptrace.Syscall) (continued bool) {
-	if _, ok := e.allowedSyscalls[curr.GetName()]; !ok {
-		err := fmt.Errorf("syscall: IllegalCall: func(%s)", curr.GetName())
+	if curr.IsCompat() {
+		if _, ok := e.allowedCompatSyscalls[curr.GetArch()][curr.GetName()]; !ok {
+			err := fmt.Errorf("syscall: IllegalCall: func(%s), arch(%s)", curr.GetName(), curr.GetArch())
+			e.setResultWithViolation(err)
+			return false
+		}
+
+		// the argument filters of the canonical syscall also apply, e.g. the requests of ioctl
+		if groups, ok := e.allowedSyscallArgs[curr.GetCanonicalName()]; ok {
+			return e.checkSyscallArgFilters(curr, groups)
+		}
+		return true
+	}
+
+	if _, ok := e.allowedSyscalls[curr.GetName()]; ok {
+		return true
+	}
+
+	// allowed with argument filters
+	if groups, ok := e.allowedSyscallArgs[curr.GetName()]; ok {
+		return e.checkSyscallArgFilters(curr, groups)
+	}
+
+	err := fmt.Errorf("syscall: IllegalCall: func(%s)", curr.GetName())
+	e.setResultWithViolation(err)
+	return false
+}
+
+// checkSyscallArgFilters allows the syscall if the args match any group of the filters
+func (e *Executor) checkSyscallArgFilters(curr *ptrace.Syscall, groups [][]SyscallArgFilter) (continued bool) {
+	for _, filters := range groups {
+		if matchSyscallArgFilters(curr, filters) {
+			return true
+		}
+	}
+
+	var args = make([]string, len(curr.GetArgs()))
+	for i, arg := range curr.GetArgs() {
+		args[i] = arg.String()
+	}
+	err := fmt.Errorf("syscall: IllegalArgs: func(%s), args(%s)", curr.GetName(), strings.Join(args, ", "))
+	e.setResultWithViolation(err)
+	return false
+}
+
+// syscallCounter counts the calls of a syscall, in total and in the current 1-second window
+type syscallCounter struct {
+	count       uint64
+	windowStart time.Time
+	windowCount uint64
+}
+
+func (e *Executor) HandleTracerSyscallEnterEvent_CheckLimits(pid int, curr *ptrace.Syscall) (continued bool) {
+	var name = curr.GetName()
+
+	// processes and threads
+	switch curr.GetNR() {
+	case ptrace.SYS_CLONE, ptrace.SYS_CLONE3, ptrace.SYS_FORK, ptrace.SYS_VFORK:
+		if lim := e.limits.LimitProcessCount; lim != nil && e.traceeProcCount >= *lim {
+			err := fmt.Errorf("limit: ProcessLimitExceeded: func(%s), count(%d)", name, e.traceeProcCount+1)
+			e.setResultWithLimitExceeded(err)
+			return false
+		}
+	case ptrace.SYS_OPEN, ptrace.SYS_OPENAT, ptrace.SYS_OPENAT2, ptrace.SYS_CREAT, ptrace.SYS_OPEN_BY_HANDLE_AT:
+		if lim := e.limits.LimitFileCount; lim != nil && e.traceeFileCount >= *lim {
+			err := fmt.Errorf("limit: FileLimitExceeded: func(%s), count(%d)", name, e.traceeFileCount+1)
+			e.setResultWithLimitExceeded(err)
+			return false
+		}
+	}
+
+	// per-syscall
+	var lim, ok = e.limits.LimitSyscalls[name]
+	if !ok {
+		return true
+	}
+
+	var counter, exists = e.traceeSyscalls[name]
+	if !exists {
+		counter = &syscallCounter{}
+		e.traceeSyscalls[name] = counter
+	}
+	counter.count += 1
+	if now := time.Now(); now.Sub(counter.windowStart) >= time.Second {
+		counter.windowStart = now
+		counter.windowCount = 0
+	}
+	counter.windowCount += 1
+
+	if lim.Count != nil && counter.count > *lim.Count {
+		err := fmt.Errorf("limit: SyscallCountLimitExceeded: func(%s), count(%d)", name, counter.count)
+		e.setResultWithLimitExceeded(err)
+		return false
+	}
+	if lim.Rate != nil && counter.windowCount > *lim.Rate {
+		err := fmt.Errorf("limit: SyscallRateLimitExceeded: func(%s), rate(%d/s)", name, counter.windowCount)
+		e.setResultWithLimitExceeded(err)
+		return false
+	}
+	return true
+}
+
+func (e *Executor) HandleTracerSyscallEnterEvent_CheckCloneFlags(pid int, curr *ptrace.Syscall) (continued bool) {
+	var flags uint64
+	switch curr.GetNR() {
+	case ptrace.SYS_CLONE, ptrace.SYS_UNSHARE:
+		flags = uint64(curr.GetArg(0).GetFlag())
+	case ptrace.SYS_CLONE3:
+		flags = curr.GetArg(0).GetCloneArgs().Flags
+	default:
+		return true
+	}
+
+	if denied := flags & e.deniedCloneFlags; denied != 0 {
+		err := fmt.Errorf("clone: IllegalFlags: %s(%s)", curr.GetName(), ptrace.FlagClone(denied))
 		e.setResultWithViolation(err)
 		return false
 	}
@@ -94,33 +368,54 @@ func (e *Executor) HandleTracerSyscallEnterEvent_CheckFileAccess(pid int, curr *
 	var nr = curr.GetNR()
 	switch nr {
 	// read
-	case unix.SYS_READ:
+	case ptrace.SYS_READ, ptrace.SYS_READV, ptrace.SYS_PREAD64, ptrace.SYS_PREADV, ptrace.SYS_PREADV2, ptrace.SYS_RECVFROM:
 		dirfd = curr.GetArg(0).GetFd()
 		path = ""
 		goto CHECK_READABLE
 
 	// write
-	case unix.SYS_WRITE:
+	case ptrace.SYS_WRITE, ptrace.SYS_WRITEV, ptrace.SYS_PWRITE64, ptrace.SYS_PWRITEV, ptrace.SYS_PWRITEV2, ptrace.SYS_SENDTO:
 		dirfd = curr.GetArg(0).GetFd()
 		path = ""
 		goto CHECK_WRITEABLE
 
+	// sendfile
+	case ptrace.SYS_SENDFILE:
+		dirfd = curr.GetArg(1).GetFd() // in_fd
+		path = ""
+		filter = e.traceeFsFilters[pid]
+		if ok, _ := filter.AllowRead(path, dirfd); !ok {
+			err := fmt.Errorf("fsfilter: ReadDisallowed: path(%s), dirfd(%d)", path, dirfd)
+			e.setResultWithFsViolation(err, fsfilter.FILE_RD)
+			return false
+		}
+		dirfd = curr.GetArg(0).GetFd() // out_fd
+		goto CHECK_WRITEABLE
+
 	// open
-	case unix.SYS_OPEN, unix.SYS_OPENAT, unix.SYS_CREAT:
+	case ptrace.SYS_OPEN, ptrace.SYS_OPENAT, ptrace.SYS_OPENAT2, ptrace.SYS_CREAT, ptrace.SYS_OPEN_BY_HANDLE_AT:
 		var flag int
 		switch nr {
-		case unix.SYS_OPEN:
+		case ptrace.SYS_OPEN:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
 			flag = curr.GetArg(1).GetFlag()
-		case unix.SYS_OPENAT:
+		case ptrace.SYS_OPENAT:
+			dirfd = curr.GetArg(0).GetFd()
+			path = curr.GetArg(1).GetPath()
+			flag = curr.GetArg(2).GetFlag()
+		case ptrace.SYS_OPENAT2:
 			dirfd = curr.GetArg(0).GetFd()
 			path = curr.GetArg(1).GetPath()
 			flag = curr.GetArg(2).GetFlag()
-		case unix.SYS_CREAT:
+		case ptrace.SYS_CREAT:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
 			flag = unix.O_CREAT | unix.O_WRONLY | unix.O_TRUNC
+		case ptrace.SYS_OPEN_BY_HANDLE_AT: // the handle may refer to any file in the mount, so check the mount itself
+			dirfd = curr.GetArg(0).GetFd()
+			path = ""
+			flag = curr.GetArg(2).GetFlag()
 		}
 
 		flag = flag &^ unix.O_CLOEXEC
@@ -133,55 +428,55 @@ func (e *Executor) HandleTracerSyscallEnterEvent_CheckFileAccess(pid int, curr *
 		}
 
 	// stat
-	case unix.SYS_STAT, unix.SYS_FSTAT, unix.SYS_LSTAT, unix.SYS_NEWFSTATAT, unix.SYS_STATX:
+	case ptrace.SYS_STAT, ptrace.SYS_FSTAT, ptrace.SYS_LSTAT, ptrace.SYS_NEWFSTATAT, ptrace.SYS_STATX:
 		switch nr {
-		case unix.SYS_STAT:
+		case ptrace.SYS_STAT:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
-		case unix.SYS_LSTAT:
+		case ptrace.SYS_LSTAT:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
-		case unix.SYS_FSTAT:
+		case ptrace.SYS_FSTAT:
 			dirfd = curr.GetArg(0).GetFd()
 			path = ""
-		case unix.SYS_NEWFSTATAT:
+		case ptrace.SYS_NEWFSTATAT:
 			dirfd = curr.GetArg(0).GetFd()
 			path = curr.GetArg(1).GetPath()
-		case unix.SYS_STATX:
+		case ptrace.SYS_STATX:
 			dirfd = curr.GetArg(0).GetFd()
 			path = curr.GetArg(1).GetPath()
 		}
 		goto CHECK_READABLE
 
 	// access
-	case unix.SYS_ACCESS, unix.SYS_FACCESSAT, unix.SYS_FACCESSAT2:
+	case ptrace.SYS_ACCESS, ptrace.SYS_FACCESSAT, ptrace.SYS_FACCESSAT2:
 		switch nr {
-		case unix.SYS_ACCESS:
+		case ptrace.SYS_ACCESS:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
-		case unix.SYS_FACCESSAT:
+		case ptrace.SYS_FACCESSAT:
 			dirfd = curr.GetArg(0).GetFd()
 			path = curr.GetArg(1).GetPath()
-		case unix.SYS_FACCESSAT2:
+		case ptrace.SYS_FACCESSAT2:
 			dirfd = curr.GetArg(0).GetFd()
 			path = curr.GetArg(1).GetPath()
 		}
 		goto CHECK_READABLE
 
 	// rename
-	case unix.SYS_RENAME, unix.SYS_RENAMEAT, unix.SYS_RENAMEAT2:
+	case ptrace.SYS_RENAME, ptrace.SYS_RENAMEAT, ptrace.SYS_RENAMEAT2:
 		switch nr {
-		case unix.SYS_RENAME:
+		case ptrace.SYS_RENAME:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
 			dirfd2 = unix.AT_FDCWD
 			path2 = curr.GetArg(1).GetPath()
-		case unix.SYS_RENAMEAT:
+		case ptrace.SYS_RENAMEAT:
 			dirfd = curr.GetArg(0).GetFd()
 			path = curr.GetArg(1).GetPath()
 			dirfd2 = curr.GetArg(2).GetFd()
 			path2 = curr.GetArg(3).GetPath()
-		case unix.SYS_RENAMEAT2:
+		case ptrace.SYS_RENAMEAT2:
 			dirfd = curr.GetArg(0).GetFd()
 			path = curr.GetArg(1).GetPath()
 			dirfd2 = curr.GetArg(2).GetFd()
@@ -190,50 +485,50 @@ func (e *Executor) HandleTracerSyscallEnterEvent_CheckFileAccess(pid int, curr *
 		goto CHECK_WRITEABLE_2
 
 	// chdir
-	case unix.SYS_CHDIR, unix.SYS_FCHDIR:
+	case ptrace.SYS_CHDIR, ptrace.SYS_FCHDIR:
 		switch nr {
-		case unix.SYS_CHDIR:
+		case ptrace.SYS_CHDIR:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
-		case unix.SYS_FCHDIR:
+		case ptrace.SYS_FCHDIR:
 			dirfd = curr.GetArg(0).GetFd()
 			path = ""
 		}
 		goto CHECK_READABLE
 
 	// mkdir
-	case unix.SYS_MKDIR, unix.SYS_MKDIRAT:
+	case ptrace.SYS_MKDIR, ptrace.SYS_MKDIRAT:
 		switch nr {
-		case unix.SYS_MKDIR:
+		case ptrace.SYS_MKDIR:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
-		case unix.SYS_MKDIRAT:
+		case ptrace.SYS_MKDIRAT:
 			dirfd = curr.GetArg(0).GetFd()
 			path = curr.GetArg(1).GetPath()
 		}
 		goto CHECK_WRITEABLE
 
 	// readlink
-	case unix.SYS_READLINK, unix.SYS_READLINKAT:
+	case ptrace.SYS_READLINK, ptrace.SYS_READLINKAT:
 		switch nr {
-		case unix.SYS_READLINK:
+		case ptrace.SYS_READLINK:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
-		case unix.SYS_READLINKAT:
+		case ptrace.SYS_READLINKAT:
 			dirfd = curr.GetArg(0).GetFd()
 			path = curr.GetArg(1).GetPath()
 		}
 		goto CHECK_READABLE
 
 	// link
-	case unix.SYS_LINK, unix.SYS_LINKAT:
+	case ptrace.SYS_LINK, ptrace.SYS_LINKAT:
 		switch nr {
-		case unix.SYS_LINK:
+		case ptrace.SYS_LINK:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
 			dirfd2 = unix.AT_FDCWD
 			path2 = curr.GetArg(1).GetPath()
-		case unix.SYS_LINKAT:
+		case ptrace.SYS_LINKAT:
 			dirfd = curr.GetArg(0).GetFd()
 			path = curr.GetArg(1).GetPath()
 			dirfd2 = curr.GetArg(2).GetFd()
@@ -242,14 +537,14 @@ func (e *Executor) HandleTracerSyscallEnterEvent_CheckFileAccess(pid int, curr *
 		goto CHECK_WRITEABLE_2
 
 	// symlink
-	case unix.SYS_SYMLINK, unix.SYS_SYMLINKAT:
+	case ptrace.SYS_SYMLINK, ptrace.SYS_SYMLINKAT:
 		switch nr {
-		case unix.SYS_SYMLINK:
+		case ptrace.SYS_SYMLINK:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
 			dirfd2 = unix.AT_FDCWD
 			path2 = curr.GetArg(1).GetPath()
-		case unix.SYS_SYMLINKAT:
+		case ptrace.SYS_SYMLINKAT:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
 			dirfd2 = curr.GetArg(1).GetFd()
@@ -258,79 +553,266 @@ func (e *Executor) HandleTracerSyscallEnterEvent_CheckFileAccess(pid int, curr *
 		goto CHECK_WRITEABLE_2
 
 	// unlink
-	case unix.SYS_UNLINK, unix.SYS_UNLINKAT:
+	case ptrace.SYS_UNLINK, ptrace.SYS_UNLINKAT:
 		switch nr {
-		case unix.SYS_UNLINK:
+		case ptrace.SYS_UNLINK:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
-		case unix.SYS_UNLINKAT:
+		case ptrace.SYS_UNLINKAT:
 			dirfd = curr.GetArg(0).GetFd()
 			path = curr.GetArg(1).GetPath()
 		}
 		goto CHECK_WRITEABLE
 
 	// chmod
-	case unix.SYS_CHMOD, unix.SYS_FCHMOD, unix.SYS_FCHMODAT:
+	case ptrace.SYS_CHMOD, ptrace.SYS_FCHMOD, ptrace.SYS_FCHMODAT:
 		switch nr {
-		case unix.SYS_CHMOD:
+		case ptrace.SYS_CHMOD:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
-		case unix.SYS_FCHMOD:
+		case ptrace.SYS_FCHMOD:
 			dirfd = curr.GetArg(0).GetFd()
 			path = ""
-		case unix.SYS_FCHMODAT:
+		case ptrace.SYS_FCHMODAT:
 			dirfd = curr.GetArg(0).GetFd()
 			path = curr.GetArg(1).GetPath()
 		}
 		goto CHECK_WRITEABLE
 
 	// statfs
-	case unix.SYS_STATFS, unix.SYS_FSTATFS:
+	case ptrace.SYS_STATFS, ptrace.SYS_FSTATFS:
 		switch nr {
-		case unix.SYS_STATFS:
+		case ptrace.SYS_STATFS:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
-		case unix.SYS_FSTATFS:
+		case ptrace.SYS_FSTATFS:
 			dirfd = curr.GetArg(0).GetFd()
 			path = ""
 		}
 		goto CHECK_READABLE
 
 	// getxattr
-	case unix.SYS_GETXATTR, unix.SYS_LGETXATTR, unix.SYS_FGETXATTR:
+	case ptrace.SYS_GETXATTR, ptrace.SYS_LGETXATTR, ptrace.SYS_FGETXATTR:
+		switch nr {
+		case ptrace.SYS_GETXATTR:
+			dirfd = unix.AT_FDCWD
+			path = curr.GetArg(0).GetPath()
+		case ptrace.SYS_LGETXATTR:
+			dirfd = unix.AT_FDCWD
+			path = curr.GetArg(0).GetPath()
+		case ptrace.SYS_FGETXATTR:
+			dirfd = curr.GetArg(0).GetFd()
+			path = ""
+		}
+		goto CHECK_READABLE
+
+	// listxattr
+	case ptrace.SYS_LISTXATTR, ptrace.SYS_LLISTXATTR, ptrace.SYS_FLISTXATTR:
+		switch nr {
+		case ptrace.SYS_LISTXATTR:
+			dirfd = unix.AT_FDCWD
+			path = curr.GetArg(0).GetPath()
+		case ptrace.SYS_LLISTXATTR:
+			dirfd = unix.AT_FDCWD
+			path = curr.GetArg(0).GetPath()
+		case ptrace.SYS_FLISTXATTR:
+			dirfd = curr.GetArg(0).GetFd()
+			path = ""
+		}
+		goto CHECK_READABLE
+
+	// setxattr
+	case ptrace.SYS_SETXATTR, ptrace.SYS_LSETXATTR, ptrace.SYS_FSETXATTR:
+		switch nr {
+		case ptrace.SYS_SETXATTR:
+			dirfd = unix.AT_FDCWD
+			path = curr.GetArg(0).GetPath()
+		case ptrace.SYS_LSETXATTR:
+			dirfd = unix.AT_FDCWD
+			path = curr.GetArg(0).GetPath()
+		case ptrace.SYS_FSETXATTR:
+			dirfd = curr.GetArg(0).GetFd()
+			path = ""
+		}
+		goto CHECK_WRITEABLE
+
+	// removexattr
+	case ptrace.SYS_REMOVEXATTR, ptrace.SYS_LREMOVEXATTR, ptrace.SYS_FREMOVEXATTR:
 		switch nr {
-		case unix.SYS_GETXATTR:
+		case ptrace.SYS_REMOVEXATTR:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
-		case unix.SYS_LGETXATTR:
+		case ptrace.SYS_LREMOVEXATTR:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
-		case unix.SYS_FGETXATTR:
+		case ptrace.SYS_FREMOVEXATTR:
 			dirfd = curr.GetArg(0).GetFd()
+			path = ""
+		}
+		goto CHECK_WRITEABLE
+
+	// truncate
+	case ptrace.SYS_TRUNCATE, ptrace.SYS_FTRUNCATE:
+		switch nr {
+		case ptrace.SYS_TRUNCATE:
+			dirfd = unix.AT_FDCWD
+			path = curr.GetArg(0).GetPath()
+		case ptrace.SYS_FTRUNCATE:
+			dirfd = curr.GetArg(0).GetFd()
+			path = ""
+		}
+		goto CHECK_WRITEABLE
+
+	// chown
+	case ptrace.SYS_CHOWN, ptrace.SYS_FCHOWN, ptrace.SYS_LCHOWN, ptrace.SYS_FCHOWNAT:
+		switch nr {
+		case ptrace.SYS_CHOWN:
+			dirfd = unix.AT_FDCWD
+			path = curr.GetArg(0).GetPath()
+		case ptrace.SYS_FCHOWN:
+			dirfd = curr.GetArg(0).GetFd()
+			path = ""
+		case ptrace.SYS_LCHOWN:
+			dirfd = unix.AT_FDCWD
+			path = curr.GetArg(0).GetPath()
+		case ptrace.SYS_FCHOWNAT:
+			dirfd = curr.GetArg(0).GetFd()
+			path = curr.GetArg(1).GetPath()
+		}
+		goto CHECK_WRITEABLE
+
+	// utime
+	case ptrace.SYS_UTIME, ptrace.SYS_UTIMES, ptrace.SYS_FUTIMESAT, ptrace.SYS_UTIMENSAT:
+		switch nr {
+		case ptrace.SYS_UTIME:
+			dirfd = unix.AT_FDCWD
+			path = curr.GetArg(0).GetPath()
+		case ptrace.SYS_UTIMES:
+			dirfd = unix.AT_FDCWD
+			path = curr.GetArg(0).GetPath()
+		case ptrace.SYS_FUTIMESAT, ptrace.SYS_UTIMENSAT:
+			dirfd = curr.GetArg(0).GetFd()
+			path = curr.GetArg(1).GetPath()
+			if curr.GetArg(1).IsNil() { // operate on dirfd itself
+				path = ""
+			}
+		}
+		goto CHECK_WRITEABLE
+
+	// mknod
+	case ptrace.SYS_MKNOD, ptrace.SYS_MKNODAT:
+		switch nr {
+		case ptrace.SYS_MKNOD:
+			dirfd = unix.AT_FDCWD
+			path = curr.GetArg(0).GetPath()
+		case ptrace.SYS_MKNODAT:
+			dirfd = curr.GetArg(0).GetFd()
+			path = curr.GetArg(1).GetPath()
+		}
+		goto CHECK_WRITEABLE
+
+	// rmdir
+	case ptrace.SYS_RMDIR:
+		dirfd = unix.AT_FDCWD
+		path = curr.GetArg(0).GetPath()
+		goto CHECK_WRITEABLE
+
+	// getdents
+	case ptrace.SYS_GETDENTS, ptrace.SYS_GETDENTS64:
+		dirfd = curr.GetArg(0).GetFd()
+		path = ""
+		goto CHECK_READABLE
+
+	// inotify
+	case ptrace.SYS_INOTIFY_ADD_WATCH:
+		dirfd = unix.AT_FDCWD
+		path = curr.GetArg(1).GetPath()
+		goto CHECK_READABLE
+
+	// fanotify
+	case ptrace.SYS_FANOTIFY_MARK:
+		dirfd = curr.GetArg(3).GetFd()
+		path = curr.GetArg(4).GetPath()
+		if curr.GetArg(4).IsNil() { // mark dirfd itself
+			path = ""
+		}
+		goto CHECK_READABLE
+
+	// name_to_handle_at
+	case ptrace.SYS_NAME_TO_HANDLE_AT:
+		dirfd = curr.GetArg(0).GetFd()
+		path = curr.GetArg(1).GetPath()
+		goto CHECK_READABLE
+
+	// mount
+	case ptrace.SYS_MOUNT, ptrace.SYS_UMOUNT2:
+		switch nr {
+		case ptrace.SYS_MOUNT:
+			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(1).GetPath()
+			if curr.GetArg(3).GetInt()&(unix.MS_BIND|unix.MS_MOVE) != 0 { // source is a file, not a device or fs name
+				dirfd2 = unix.AT_FDCWD
+				path2 = curr.GetArg(0).GetPath()
+				goto CHECK_WRITEABLE_2
+			}
+		case ptrace.SYS_UMOUNT2:
+			dirfd = unix.AT_FDCWD
+			path = curr.GetArg(0).GetPath()
 		}
+		goto CHECK_WRITEABLE
+
+	// chroot
+	case ptrace.SYS_CHROOT:
+		dirfd = unix.AT_FDCWD
+		path = curr.GetArg(0).GetPath()
 		goto CHECK_READABLE
 
+	// clone3
+	case ptrace.SYS_CLONE3:
+		var args = curr.GetArg(0).GetCloneArgs()
+		if args.Flags&unix.CLONE_INTO_CGROUP == 0 {
+			goto PASSTHROUGH
+		}
+		dirfd = int(int32(args.Cgroup))
+		path = ""
+		goto CHECK_WRITEABLE
+
 	// execve
-	case unix.SYS_EXECVE, unix.SYS_EXECVEAT:
+	case ptrace.SYS_EXECVE, ptrace.SYS_EXECVEAT:
 		switch nr {
-		case unix.SYS_EXECVE:
+		case ptrace.SYS_EXECVE:
 			dirfd = unix.AT_FDCWD
 			path = curr.GetArg(0).GetPath()
-		case unix.SYS_EXECVEAT:
+		case ptrace.SYS_EXECVEAT:
 			dirfd = curr.GetArg(0).GetFd()
 			path = curr.GetArg(1).GetPath()
 		}
 		goto CHECK_EXECUTABLE
 
 	// pass through
-	case unix.SYS_CLOSE:
+	case ptrace.SYS_CLOSE:
+		goto PASSTHROUGH
+	case ptrace.SYS_PIPE, ptrace.SYS_PIPE2:
+		goto PASSTHROUGH
+	case ptrace.SYS_DUP, ptrace.SYS_DUP2, ptrace.SYS_DUP3:
+		goto PASSTHROUGH
+	case ptrace.SYS_FCNTL:
+		goto PASSTHROUGH
+	case ptrace.SYS_CLOSE_RANGE:
+		goto PASSTHROUGH
+	case ptrace.SYS_SOCKET, ptrace.SYS_SOCKETPAIR, ptrace.SYS_ACCEPT, ptrace.SYS_ACCEPT4, ptrace.SYS_RECVMSG, ptrace.SYS_RECVMMSG:
 		goto PASSTHROUGH
-	case unix.SYS_PIPE, unix.SYS_PIPE2:
+	case ptrace.SYS_EVENTFD, ptrace.SYS_EVENTFD2, ptrace.SYS_EPOLL_CREATE, ptrace.SYS_EPOLL_CREATE1:
 		goto PASSTHROUGH
-	case unix.SYS_DUP, unix.SYS_DUP2, unix.SYS_DUP3:
+	case ptrace.SYS_TIMERFD_CREATE, ptrace.SYS_SIGNALFD, ptrace.SYS_SIGNALFD4:
 		goto PASSTHROUGH
-	case unix.SYS_FCNTL:
+	case ptrace.SYS_INOTIFY_INIT, ptrace.SYS_INOTIFY_INIT1, ptrace.SYS_FANOTIFY_INIT:
+		goto PASSTHROUGH
+	case ptrace.SYS_MEMFD_CREATE, ptrace.SYS_USERFAULTFD:
+		goto PASSTHROUGH
+	case ptrace.SYS_PIDFD_OPEN, ptrace.SYS_PIDFD_GETFD:
+		goto PASSTHROUGH
+	case ptrace.SYS_LSEEK, ptrace.SYS_MMAP, ptrace.SYS_IOCTL, ptrace.SYS_FLOCK, ptrace.SYS_FSYNC, ptrace.SYS_FDATASYNC:
 		goto PASSTHROUGH
 
 	// not implemented
@@ -352,10 +834,10 @@ CHECK_READABLE:
 	filter = e.traceeFsFilters[pid]
 	if ok, _ := filter.AllowRead(path, dirfd); !ok {
 		err := fmt.Errorf("fsfilter: ReadDisallowed: path(%s), dirfd(%d)", path, dirfd)
-		e.setResultWithViolation(err)
+		e.setResultWithFsViolation(err, fsfilter.FILE_RD)
 		return false
 	} else {
-		e.info("syscall: Enter:   => fsfilter: ReadAllowed")
+		e.log(LOG_LEVEL_SYSCALL, "fsfilter", "syscall", curr.GetName(), "path", path, "perm", "read", "decision", TRACE_DECISION_ALLOW)
 		return true
 	}
 
@@ -363,10 +845,10 @@ CHECK_WRITEABLE:
 	filter = e.traceeFsFilters[pid]
 	if ok, _ := filter.AllowWrite(path, dirfd); !ok {
 		err := fmt.Errorf("fsfilter: WriteDisallowed: path(%s), dirfd(%d)", path, dirfd)
-		e.setResultWithViolation(err)
+		e.setResultWithFsViolation(err, fsfilter.FILE_WR)
 		return false
 	} else {
-		e.info("syscall: Enter:   => fsfiter: WriteAllowed")
+		e.log(LOG_LEVEL_SYSCALL, "fsfilter", "syscall", curr.GetName(), "path", path, "perm", "write", "decision", TRACE_DECISION_ALLOW)
 		return true
 	}
 
@@ -374,14 +856,14 @@ CHECK_WRITEABLE_2:
 	filter = e.traceeFsFilters[pid]
 	if ok, _ := filter.AllowWrite(path, dirfd); !ok {
 		err := fmt.Errorf("fsfilter: WriteDisallowed: path(%s), dirfd(%d), path2(%s), dirfd2(%d)", path, dirfd, path2, dirfd2)
-		e.setResultWithViolation(err)
+		e.setResultWithFsViolation(err, fsfilter.FILE_WR)
 		return false
 	} else if ok, _ := filter.AllowWrite(path2, dirfd2); !ok {
 		err := fmt.Errorf("fsfilter: WriteDisallowed: path(%s), dirfd(%d), path2(%s), dirfd2(%d)", path, dirfd, path2, dirfd2)
-		e.setResultWithViolation(err)
+		e.setResultWithFsViolation(err, fsfilter.FILE_WR)
 		return false
 	} else {
-		e.info("syscall: Enter:   => fsfiter: WriteAllowed")
+		e.log(LOG_LEVEL_SYSCALL, "fsfilter", "syscall", curr.GetName(), "path", path, "perm", "write", "decision", TRACE_DECISION_ALLOW)
 		return true
 	}
 
@@ -389,10 +871,10 @@ CHECK_EXECUTABLE:
 	filter = e.traceeFsFilters[pid]
 	if ok, _ := filter.AllowExecute(path, dirfd); !ok {
 		err := fmt.Errorf("fsfilter: ExecuteDisallowed: path(%s), dirfd(%d)", path, dirfd)
-		e.setResultWithViolation(err)
+		e.setResultWithFsViolation(err, fsfilter.FILE_EX)
 		return false
 	} else {
-		e.info("syscall: Enter:   => fsfilter: ExecuteAllowed")
+		e.log(LOG_LEVEL_SYSCALL, "fsfilter", "syscall", curr.GetName(), "path", path, "perm", "execute", "decision", TRACE_DECISION_ALLOW)
 		return true
 	}
 
@@ -400,15 +882,96 @@ PASSTHROUGH:
 	return true
 }
 
+func (e *Executor) HandleTracerSyscallEnterEvent_CheckExec(pid int, curr *ptrace.Syscall) (continued bool) {
+	var (
+		dirfd int
+		path  string
+		argv  []string
+	)
+
+	switch curr.GetNR() {
+	case ptrace.SYS_EXECVE:
+		dirfd = unix.AT_FDCWD
+		path = curr.GetArg(0).GetPath()
+		argv = curr.GetArg(1).GetStringArray()
+	case ptrace.SYS_EXECVEAT:
+		dirfd = curr.GetArg(0).GetFd()
+		path = curr.GetArg(1).GetPath()
+		argv = curr.GetArg(2).GetStringArray()
+	default:
+		return true
+	}
+
+	// count
+	if lim := e.limits.LimitExecCount; lim != nil && e.traceeExecCount >= *lim {
+		err := fmt.Errorf("exec: CountLimitExceeded: count(%d)", e.traceeExecCount+1)
+		e.setResultWithLimitExceeded(err)
+		return false
+	}
+
+	// depth
+	if lim := e.limits.LimitExecDepth; lim != nil && e.traceeExecDepth[pid] >= *lim {
+		err := fmt.Errorf("exec: DepthLimitExceeded: depth(%d)", e.traceeExecDepth[pid]+1)
+		e.setResultWithLimitExceeded(err)
+		return false
+	}
+
+	// args
+	var filter = e.traceeFsFilters[pid]
+	var fullpath, err = filter.GetFullpath(path, dirfd)
+	if err != nil {
+		return true // let the kernel report the error
+	}
+	if patterns, ok := e.execRules[resolveExecPath(fullpath)]; ok && len(argv) > 1 {
+		for _, arg := range argv[1:] {
+			var matched = false
+			for _, pattern := range patterns {
+				if pattern.MatchString(arg) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				err := fmt.Errorf("exec: IllegalArgs: path(%s), arg(%q)", fullpath, arg)
+				e.setResultWithViolation(err)
+				return false
+			}
+		}
+		e.log(LOG_LEVEL_SYSCALL, "exec: Args", "path", fullpath, "decision", TRACE_DECISION_ALLOW)
+	}
+
+	// interpreter, e.g. shebang(#!), PT_INTERP
+	for i := 0; i < maxInterpreterDepth; i++ {
+		interp, err := filter.GetInterpreter(path, dirfd)
+		if err != nil || interp == "" {
+			break
+		}
+		if ok, _ := filter.AllowExecute(interp, unix.AT_FDCWD); !ok {
+			err := fmt.Errorf("exec: InterpreterDisallowed: path(%s), interp(%s)", fullpath, interp)
+			e.setResultWithFsViolation(err, fsfilter.FILE_EX)
+			return false
+		}
+		e.log(LOG_LEVEL_SYSCALL, "exec: Interpreter", "path", interp, "decision", TRACE_DECISION_ALLOW)
+		dirfd, path = unix.AT_FDCWD, interp
+	}
+
+	return true
+}
+
 func (e *Executor) HandleTracerSyscallLeaveEvent(pid int, curr *ptrace.Syscall, prev *ptrace.Syscall) (continued bool) {
 	e.traceePid = pid
 	defer func() {
 		e.traceePid = 0
 	}()
 
+	// metrics
+	if e.metrics != nil {
+		e.metrics.TracerStop(TRACER_STOP_SYSCALL_LEAVE)
+	}
+
 	// special case
-	if curr.GetNR() == unix.SYS_EXIT || curr.GetNR() == unix.SYS_EXIT_GROUP {
-		e.info("syscall: Leave:   => retval: ?")
+	if curr.GetNR() == ptrace.SYS_EXIT || curr.GetNR() == ptrace.SYS_EXIT_GROUP {
+		e.log(LOG_LEVEL_SYSCALL, "syscall: Leave", "syscall", curr.GetName())
 		return true
 	}
 
@@ -418,10 +981,15 @@ func (e *Executor) HandleTracerSyscallLeaveEvent(pid int, curr *ptrace.Syscall,
 		return false
 	}
 
-	// ENOSYS - which is put into RAX as a default return value by the kernel's syscall entry code
+	// trace
 	var retval = curr.GetRetval()
+	if e.tracer != nil {
+		e.tracer.leave(pid, retval)
+	}
+
+	// ENOSYS - which is put into RAX as a default return value by the kernel's syscall entry code
 	if retval.HasError_ENOSYS() {
-		e.info(fmt.Sprintf("syscall: Leave:   => retval: %s", retval))
+		e.log(LOG_LEVEL_SYSCALL, "syscall: Leave", "syscall", curr.GetName(), "retval", retval)
 		e.setResultWithSandboxFailure(fmt.Errorf("ptrace: ENOSYS: %s(...) = %s", curr.GetName(), syscall.ENOSYS))
 		return false
 	}
@@ -431,13 +999,141 @@ func (e *Executor) HandleTracerSyscallLeaveEvent(pid int, curr *ptrace.Syscall,
 		return false
 	}
 
+	// process tree, report
+	e.HandleTracerSyscallLeaveEvent_Report(pid, curr, prev)
+
+	// limits - restrict stdout/stderr size
+	if continued := e.HandleTracerSyscallLeaveEvent_CheckOutput(pid, curr, prev); !continued {
+		return false
+	}
+
+	// limits - memory allocations refused by RLIMIT_AS
+	e.HandleTracerSyscallLeaveEvent_CheckMemory(pid, curr, prev)
+
 	// logging
-	e.info(fmt.Sprintf("syscall: Leave:   => retval: %s", retval))
+	e.log(LOG_LEVEL_SYSCALL, "syscall: Leave", "syscall", curr.GetName(), "retval", retval)
 
 	// ok
 	return true
 }
 
+func (e *Executor) HandleTracerSyscallLeaveEvent_Report(pid int, curr *ptrace.Syscall, prev *ptrace.Syscall) {
+	var retval = curr.GetRetval()
+	var nr = curr.GetNR()
+
+	// process tree
+	if (nr == ptrace.SYS_EXECVE || nr == ptrace.SYS_EXECVEAT) && !retval.HasError() && prev != nil {
+		if p, ok := e.traceeProcs[pid]; ok {
+			if nr == ptrace.SYS_EXECVE {
+				p.Argv = prev.GetArg(1).GetStringArray()
+			} else {
+				p.Argv = prev.GetArg(2).GetStringArray()
+			}
+		}
+	}
+
+	var r = e.Result.Report
+	if r == nil {
+		return
+	}
+
+	// syscall statistics
+	if t, ok := e.traceeEnterT[pid]; ok {
+		delete(e.traceeEnterT, pid)
+		r.addSyscallTime(curr.GetName(), time.Since(t), retval.HasError())
+	}
+	if retval.HasError() {
+		return
+	}
+
+	// I/O statistics
+	switch nr {
+	case ptrace.SYS_READ, ptrace.SYS_READV, ptrace.SYS_PREAD64, ptrace.SYS_PREADV, ptrace.SYS_PREADV2,
+		ptrace.SYS_RECVFROM, ptrace.SYS_RECVMSG:
+		r.BytesRead += uint64(retval.GetValue())
+	case ptrace.SYS_WRITE, ptrace.SYS_WRITEV, ptrace.SYS_PWRITE64, ptrace.SYS_PWRITEV, ptrace.SYS_PWRITEV2,
+		ptrace.SYS_SENDTO, ptrace.SYS_SENDMSG, ptrace.SYS_SENDFILE:
+		r.BytesWritten += uint64(retval.GetValue())
+	case ptrace.SYS_OPEN, ptrace.SYS_OPENAT, ptrace.SYS_OPENAT2, ptrace.SYS_CREAT, ptrace.SYS_OPEN_BY_HANDLE_AT:
+		if filter, ok := e.traceeFsFilters[pid]; ok {
+			if f, err := filter.GetTrackdFile(retval.GetValue()); err == nil {
+				r.addFileOpened(f.GetFullpath())
+			}
+		}
+	}
+}
+
+func (e *Executor) HandleTracerSyscallLeaveEvent_CheckOutput(pid int, curr *ptrace.Syscall, prev *ptrace.Syscall) (continued bool) {
+	var retval = curr.GetRetval()
+	if retval.HasError() || retval.GetValue() == 0 || prev == nil {
+		return true
+	}
+
+	// Syscall arg - fd written to
+	var fd int
+	switch curr.GetNR() {
+	case ptrace.SYS_WRITE, ptrace.SYS_WRITEV, ptrace.SYS_PWRITE64, ptrace.SYS_PWRITEV, ptrace.SYS_PWRITEV2, ptrace.SYS_SENDFILE:
+		fd = prev.GetArg(0).GetFd()
+	case ptrace.SYS_SPLICE:
+		fd = int(int32(prev.GetArgValue(2)))
+	case ptrace.SYS_TEE:
+		fd = int(int32(prev.GetArgValue(1)))
+	default:
+		return true
+	}
+
+	// stdout/stderr, including the dup-ed ones
+	var filter, ok = e.traceeFsFilters[pid]
+	if !ok {
+		return true
+	}
+	var f, err = filter.GetTrackdFile(fd)
+	if err != nil {
+		return true
+	}
+
+	var n = uint64(retval.GetValue())
+	var r = &e.Result
+	switch {
+	case f.IsStdout():
+		r.StdoutBytes += n
+		if lim := e.limits.LimitStdout; lim != nil && r.StdoutBytes > *lim && e.flags[FLAG_TRUNCATE_OUTPUT] != ENABLED {
+			err := fmt.Errorf("limit: OutputLimitExceeded: stdout(%d)", r.StdoutBytes)
+			e.setResultWithOutputLimitExceeded(err)
+			return false
+		}
+	case f.IsStderr():
+		r.StderrBytes += n
+		if lim := e.limits.LimitStderr; lim != nil && r.StderrBytes > *lim && e.flags[FLAG_TRUNCATE_OUTPUT] != ENABLED {
+			err := fmt.Errorf("limit: OutputLimitExceeded: stderr(%d)", r.StderrBytes)
+			e.setResultWithOutputLimitExceeded(err)
+			return false
+		}
+	}
+	return true
+}
+
+// HandleTracerSyscallLeaveEvent_CheckMemory records the first allocation refused by RLIMIT_AS. The program may
+// recover from it, so the run is reported as StatusMemoryLimitExceeded only if it fails afterwards.
+func (e *Executor) HandleTracerSyscallLeaveEvent_CheckMemory(pid int, curr *ptrace.Syscall, prev *ptrace.Syscall) {
+	if e.limits.RlimitAS == nil || e.traceeOOM != nil || prev == nil {
+		return
+	}
+
+	var retval = curr.GetRetval()
+	var refused bool
+	switch curr.GetNR() {
+	case ptrace.SYS_MMAP, ptrace.SYS_MREMAP:
+		refused = retval.HasError() && retval.GetErrno() == syscall.ENOMEM
+	case ptrace.SYS_BRK: // returns the current program break on failure
+		refused = prev.GetArgValue(0) > uint64(retval.GetValue())
+	}
+	if refused {
+		e.traceeOOM = fmt.Errorf("limit: MemoryLimitExceeded: func(%s), as(%d)", curr.GetName(), *e.limits.RlimitAS)
+		e.log(LOG_LEVEL_PROCESS, "proc: AllocationRefused", "syscall", curr.GetName(), "retval", retval)
+	}
+}
+
 func (e *Executor) HandleTracerSyscallLeaveEvent_TraceFd(pid int, curr *ptrace.Syscall, prev *ptrace.Syscall) (continued bool) {
 	var retval = curr.GetRetval()
 	if retval.HasError() {
@@ -448,19 +1144,30 @@ func (e *Executor) HandleTracerSyscallLeaveEvent_TraceFd(pid int, curr *ptrace.S
 	var nr = curr.GetNR()
 	switch nr {
 	// open
-	case unix.SYS_OPEN, unix.SYS_OPENAT, unix.SYS_CREAT:
+	case ptrace.SYS_OPEN, ptrace.SYS_OPENAT, ptrace.SYS_OPENAT2, ptrace.SYS_CREAT, ptrace.SYS_OPEN_BY_HANDLE_AT:
 		var dirfd int
 		var path string
+		var flag int
 		switch nr {
-		case unix.SYS_OPEN:
+		case ptrace.SYS_OPEN:
 			dirfd = unix.AT_FDCWD
 			path = prev.GetArg(0).GetPath()
-		case unix.SYS_OPENAT:
+			flag = prev.GetArg(1).GetFlag()
+		case ptrace.SYS_OPENAT:
+			dirfd = prev.GetArg(0).GetFd()
+			path = prev.GetArg(1).GetPath()
+			flag = prev.GetArg(2).GetFlag()
+		case ptrace.SYS_OPENAT2:
 			dirfd = prev.GetArg(0).GetFd()
 			path = prev.GetArg(1).GetPath()
-		case unix.SYS_CREAT:
+			flag = prev.GetArg(2).GetFlag()
+		case ptrace.SYS_CREAT:
 			dirfd = unix.AT_FDCWD
 			path = prev.GetArg(0).GetPath()
+		case ptrace.SYS_OPEN_BY_HANDLE_AT:
+			dirfd = prev.GetArg(0).GetFd()
+			path = ""
+			flag = prev.GetArg(2).GetFlag()
 		}
 
 		f, err := filter.TrackFd(retval.GetValue(), path, dirfd)
@@ -469,54 +1176,238 @@ func (e *Executor) HandleTracerSyscallLeaveEvent_TraceFd(pid int, curr *ptrace.S
 			e.setResultWithSandboxFailure(err)
 			return false
 		}
-		e.info(fmt.Sprintf("syscall: Leave:   => fsfilter: TRACK: %s <=> %s", ptrace.Fd(retval.GetValue()), f.GetFullpath()))
+		filter.SetCloexec(retval.GetValue(), flag&unix.O_CLOEXEC != 0)
+		e.traceeFileCount += 1
+		e.log(LOG_LEVEL_FD, "fsfilter: Track", "fd", ptrace.Fd(retval.GetValue()), "path", f.GetFullpath())
+
+	// anonymous fd, e.g. socket, eventfd
+	case ptrace.SYS_SOCKET, ptrace.SYS_ACCEPT, ptrace.SYS_ACCEPT4,
+		ptrace.SYS_EVENTFD, ptrace.SYS_EVENTFD2, ptrace.SYS_EPOLL_CREATE, ptrace.SYS_EPOLL_CREATE1,
+		ptrace.SYS_TIMERFD_CREATE, ptrace.SYS_SIGNALFD, ptrace.SYS_SIGNALFD4,
+		ptrace.SYS_INOTIFY_INIT, ptrace.SYS_INOTIFY_INIT1, ptrace.SYS_FANOTIFY_INIT,
+		ptrace.SYS_MEMFD_CREATE, ptrace.SYS_USERFAULTFD, ptrace.SYS_PIDFD_OPEN:
+		var fd = retval.GetValue()
+		var perm = fsfilter.FILE_WR
+		var cloexec bool
+		switch nr {
+		case ptrace.SYS_SOCKET:
+			cloexec = prev.GetArg(1).GetInt()&unix.SOCK_CLOEXEC != 0
+		case ptrace.SYS_ACCEPT:
+			cloexec = false
+		case ptrace.SYS_ACCEPT4:
+			cloexec = prev.GetArg(3).GetInt()&unix.SOCK_CLOEXEC != 0
+		case ptrace.SYS_EVENTFD:
+			cloexec = false
+		case ptrace.SYS_EVENTFD2:
+			cloexec = prev.GetArg(1).GetInt()&unix.EFD_CLOEXEC != 0
+		case ptrace.SYS_EPOLL_CREATE:
+			cloexec = false
+		case ptrace.SYS_EPOLL_CREATE1:
+			cloexec = prev.GetArg(0).GetInt()&unix.EPOLL_CLOEXEC != 0
+		case ptrace.SYS_TIMERFD_CREATE:
+			perm = fsfilter.FILE_RD
+			cloexec = prev.GetArg(1).GetInt()&unix.TFD_CLOEXEC != 0
+		case ptrace.SYS_SIGNALFD:
+			if _, err := filter.GetTrackdFile(fd); err == nil { // modify an existing signalfd
+				return true
+			}
+			perm = fsfilter.FILE_RD
+			cloexec = false
+		case ptrace.SYS_SIGNALFD4:
+			if _, err := filter.GetTrackdFile(fd); err == nil { // modify an existing signalfd
+				return true
+			}
+			perm = fsfilter.FILE_RD
+			cloexec = prev.GetArg(3).GetInt()&unix.SFD_CLOEXEC != 0
+		case ptrace.SYS_INOTIFY_INIT:
+			perm = fsfilter.FILE_RD
+			cloexec = false
+		case ptrace.SYS_INOTIFY_INIT1:
+			perm = fsfilter.FILE_RD
+			cloexec = prev.GetArg(0).GetInt()&unix.IN_CLOEXEC != 0
+		case ptrace.SYS_FANOTIFY_INIT:
+			cloexec = prev.GetArg(0).GetInt()&unix.FAN_CLOEXEC != 0
+		case ptrace.SYS_MEMFD_CREATE:
+			cloexec = prev.GetArg(1).GetInt()&unix.MFD_CLOEXEC != 0
+		case ptrace.SYS_USERFAULTFD:
+			cloexec = prev.GetArg(0).GetInt()&unix.O_CLOEXEC != 0
+		case ptrace.SYS_PIDFD_OPEN:
+			perm = fsfilter.FILE_RD
+			cloexec = true
+		}
+
+		if f, err := filter.TrackMemFd(fd, perm); err != nil {
+			err = fmt.Errorf("ptrace: %s", err.Error())
+			e.setResultWithSandboxFailure(err)
+			return false
+		} else {
+			filter.SetCloexec(fd, cloexec)
+			e.log(LOG_LEVEL_FD, "fsfilter: Track", "fd", ptrace.Fd(fd), "path", f.GetFullpath())
+		}
+
+	// fd installed by others
+	case ptrace.SYS_RECVMSG, ptrace.SYS_RECVMMSG, ptrace.SYS_PIDFD_GETFD:
+		var fds []int
+		var cloexec bool
+		switch nr {
+		case ptrace.SYS_RECVMSG:
+			if err := prev.ReadArgs(); err != nil { // the msghdr filled by kernel
+				err = fmt.Errorf("ptrace: %s", err.Error())
+				e.setResultWithSandboxFailure(err)
+				return false
+			}
+			fds = prev.GetArg(1).GetScmRights()
+			cloexec = prev.GetArg(2).GetInt()&unix.MSG_CMSG_CLOEXEC != 0
+		case ptrace.SYS_RECVMMSG:
+			if v, err := prev.ReadMmsgScmRights(retval.GetValue()); err != nil {
+				err = fmt.Errorf("ptrace: %s", err.Error())
+				e.setResultWithSandboxFailure(err)
+				return false
+			} else {
+				fds = v
+			}
+			cloexec = prev.GetArg(3).GetInt()&unix.MSG_CMSG_CLOEXEC != 0
+		case ptrace.SYS_PIDFD_GETFD:
+			fds = []int{retval.GetValue()}
+			cloexec = true
+		}
+
+		for _, fd := range fds {
+			if f, err := filter.TrackProcFd(fd); err != nil {
+				err = fmt.Errorf("ptrace: %s", err.Error())
+				e.setResultWithSandboxFailure(err)
+				return false
+			} else {
+				filter.SetCloexec(fd, cloexec)
+				e.log(LOG_LEVEL_FD, "fsfilter: Track", "fd", ptrace.Fd(fd), "path", f.GetFullpath())
+			}
+		}
+
+	// clone
+	case ptrace.SYS_CLONE, ptrace.SYS_CLONE3:
+		flags, err := prev.ReadCloneFlags()
+		if err != nil {
+			err = fmt.Errorf("ptrace: %s", err.Error())
+			e.setResultWithSandboxFailure(err)
+			return false
+		}
+		if flags&unix.CLONE_PIDFD == 0 {
+			break
+		}
+
+		fd, err := prev.ReadPidFd()
+		if err != nil {
+			err = fmt.Errorf("ptrace: %s", err.Error())
+			e.setResultWithSandboxFailure(err)
+			return false
+		}
+		if f, err := filter.TrackMemFd(fd, fsfilter.FILE_RD); err != nil {
+			err = fmt.Errorf("ptrace: %s", err.Error())
+			e.setResultWithSandboxFailure(err)
+			return false
+		} else {
+			filter.SetCloexec(fd, true)
+			e.log(LOG_LEVEL_FD, "fsfilter: Track", "fd", ptrace.Fd(fd), "path", f.GetFullpath())
+		}
+
+	// execve
+	case ptrace.SYS_EXECVE, ptrace.SYS_EXECVEAT:
+		e.traceeExecCount += 1
+		e.traceeExecDepth[pid] += 1
+		filter.UnshareFdTable() // unshare_files, e.g. a child created with CLONE_FILES stops sharing the fds
+		for _, fd := range filter.UntrackCloexecFds() {
+			e.log(LOG_LEVEL_FD, "fsfilter: Untrack", "fd", ptrace.Fd(fd))
+		}
 
 	// close
-	case unix.SYS_CLOSE:
+	case ptrace.SYS_CLOSE:
 		var fd = prev.GetArg(0).GetFd()
 		filter.UntrackFd(fd)
-		e.info(fmt.Sprintf("syscall: Leave:   => fsfilter: UNTRACK: %s", ptrace.Fd(fd)))
+		e.log(LOG_LEVEL_FD, "fsfilter: Untrack", "fd", ptrace.Fd(fd))
+
+	// close_range
+	case ptrace.SYS_CLOSE_RANGE:
+		var first = prev.GetArg(0).GetInt()
+		var last = prev.GetArg(1).GetInt()
+		var flags = prev.GetArg(2).GetInt()
+		if last < 0 { // ~0U
+			last = math.MaxInt32
+		}
+		if flags&unix.CLOSE_RANGE_UNSHARE != 0 {
+			filter.UnshareFdTable()
+		}
+		if flags&unix.CLOSE_RANGE_CLOEXEC != 0 {
+			filter.SetCloexecRange(first, last)
+			e.log(LOG_LEVEL_FD, "fsfilter: Cloexec", "first", first, "last", last)
+		} else {
+			filter.UntrackFdRange(first, last)
+			e.log(LOG_LEVEL_FD, "fsfilter: Untrack", "first", first, "last", last)
+		}
+
+	// unshare
+	case ptrace.SYS_UNSHARE:
+		if prev.GetArg(0).GetInt()&unix.CLONE_FILES != 0 {
+			filter.UnshareFdTable()
+			e.log(LOG_LEVEL_FD, "fsfilter: Unshare")
+		}
 
 	// pipe
-	case unix.SYS_PIPE, unix.SYS_PIPE2:
-		if err := curr.ReadArgs(); err != nil {
+	case ptrace.SYS_PIPE, ptrace.SYS_PIPE2, ptrace.SYS_SOCKETPAIR:
+		if err := prev.ReadArgs(); err != nil { // the fds filled by kernel
 			err = fmt.Errorf("ptrace: %s", err.Error())
 			e.setResultWithSandboxFailure(err)
-			return
+			return false
+		}
+		var pipefd []int
+		var perm_rd = fsfilter.FILE_RD
+		var cloexec bool
+		switch nr {
+		case ptrace.SYS_PIPE:
+			pipefd = prev.GetArg(0).GetPipeFd()
+			cloexec = false
+		case ptrace.SYS_PIPE2:
+			pipefd = prev.GetArg(0).GetPipeFd()
+			cloexec = prev.GetArg(1).GetInt()&unix.O_CLOEXEC != 0
+		case ptrace.SYS_SOCKETPAIR: // both ends are bidirectional
+			pipefd = prev.GetArg(3).GetPipeFd()
+			perm_rd = fsfilter.FILE_WR
+			cloexec = prev.GetArg(1).GetInt()&unix.SOCK_CLOEXEC != 0
 		}
-		var pipefd = curr.GetArg(0).GetPipeFd()
 		var fd_rd = pipefd[0]
 		var fd_wr = pipefd[1]
-		if f, err := filter.TrackMemFd(fd_rd, fsfilter.FILE_RD); err != nil {
+		if f, err := filter.TrackMemFd(fd_rd, perm_rd); err != nil {
 			err = fmt.Errorf("ptrace: %s", err.Error())
 			e.setResultWithSandboxFailure(err)
 			return false
 		} else {
-			e.info(fmt.Sprintf("syscall: Leave:   => fsfilter: TRACK: %s <=> %s", ptrace.Fd(fd_rd), f.GetFullpath()))
+			filter.SetCloexec(fd_rd, cloexec)
+			e.log(LOG_LEVEL_FD, "fsfilter: Track", "fd", ptrace.Fd(fd_rd), "path", f.GetFullpath())
 		}
 		if f, err := filter.TrackMemFd(fd_wr, fsfilter.FILE_WR); err != nil {
 			err = fmt.Errorf("ptrace: %s", err.Error())
 			e.setResultWithSandboxFailure(err)
 			return false
 		} else {
-			e.info(fmt.Sprintf("syscall: Leave:   => fsfilter: TRACK: %s <=> %s", ptrace.Fd(fd_wr), f.GetFullpath()))
+			filter.SetCloexec(fd_wr, cloexec)
+			e.log(LOG_LEVEL_FD, "fsfilter: Track", "fd", ptrace.Fd(fd_wr), "path", f.GetFullpath())
 		}
-		e.info(fmt.Sprintf("syscall: Leave:   =>   arg0: %s", curr.GetArg(0).String()))
 
 	// dup
-	case unix.SYS_DUP, unix.SYS_DUP2, unix.SYS_DUP3:
+	case ptrace.SYS_DUP, ptrace.SYS_DUP2, ptrace.SYS_DUP3:
 		var oldfd int
 		var newfd int
+		var cloexec bool
 		switch nr {
-		case unix.SYS_DUP:
+		case ptrace.SYS_DUP:
 			oldfd = prev.GetArg(0).GetFd()
 			newfd = retval.GetValue()
-		case unix.SYS_DUP2:
+		case ptrace.SYS_DUP2:
 			oldfd = prev.GetArg(0).GetFd()
 			newfd = retval.GetValue()
-		case unix.SYS_DUP3:
+		case ptrace.SYS_DUP3:
 			oldfd = prev.GetArg(0).GetFd()
 			newfd = retval.GetValue()
+			cloexec = prev.GetArg(2).GetInt()&unix.O_CLOEXEC != 0
 		}
 
 		f, err := filter.GetTrackdFile(oldfd)
@@ -530,22 +1421,24 @@ func (e *Executor) HandleTracerSyscallLeaveEvent_TraceFd(pid int, curr *ptrace.S
 			e.setResultWithSandboxFailure(err)
 			return false
 		}
-		e.info(fmt.Sprintf("syscall: Leave:   => fsfilter: TRACK: %s <=> %s <=> %s", ptrace.Fd(newfd), ptrace.Fd(oldfd), f.GetFullpath()))
+		filter.SetCloexec(newfd, cloexec)
+		e.log(LOG_LEVEL_FD, "fsfilter: Track", "fd", ptrace.Fd(newfd), "oldfd", ptrace.Fd(oldfd), "path", f.GetFullpath())
 
 	// fcntl
-	case unix.SYS_FCNTL:
+	case ptrace.SYS_FCNTL:
 		var oldfd = prev.GetArg(0).GetFd()
 		var cmd = prev.GetArg(1).GetFlag()
 		switch cmd {
 		case unix.F_GETFD:
 			break
 		case unix.F_SETFD:
-			break
+			var cloexec = prev.GetArg(2).GetInt()&unix.FD_CLOEXEC != 0
+			filter.SetCloexec(oldfd, cloexec)
 		case unix.F_GETFL:
 			break
 		case unix.F_SETFL:
 			break
-		case unix.F_DUPFD:
+		case unix.F_DUPFD, unix.F_DUPFD_CLOEXEC:
 			var newfd = retval.GetValue()
 			f, err := filter.GetTrackdFile(oldfd)
 			if err != nil {
@@ -558,7 +1451,8 @@ func (e *Executor) HandleTracerSyscallLeaveEvent_TraceFd(pid int, curr *ptrace.S
 				e.setResultWithSandboxFailure(err)
 				return false
 			}
-			e.info(fmt.Sprintf("syscall: Leave:   => fsfilter: TRACK: %s <=> %s <=> %s", ptrace.Fd(newfd), ptrace.Fd(oldfd), f.GetFullpath()))
+			filter.SetCloexec(newfd, cmd == unix.F_DUPFD_CLOEXEC)
+			e.log(LOG_LEVEL_FD, "fsfilter: Track", "fd", ptrace.Fd(newfd), "oldfd", ptrace.Fd(oldfd), "path", f.GetFullpath())
 		default:
 			err := fmt.Errorf("fsfilter: NotImplemented: %s(%s, %s, ...)", curr.GetName(), ptrace.Fd(oldfd), ptrace.FlagFcntlCmd(cmd))
 			e.setResultWithViolation(err)
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"github.com/souk4711/gsandbox"
+)
+
+func newJudgeCommand() *cobra.Command {
+	var policyFilePath string
+	var reportFilePath string
+	var checkerName string
+	var checkerProg string
+	var checkerArgs []string
+	var checkerPolicyFilePath string
+	var floatEpsilon float64
+	var logLevel int
+	var logFormat string
+	var workDir string
+	var policy string
+
+	var judgeCommand = &cobra.Command{
+		Use:   "judge [flags] CASES_DIR -- PROGRAM [ARG...]",
+		Short: "Run a program against the test cases in a sandbox",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var sandbox = gsandbox.NewSandbox()
+			var checkerSandbox = gsandbox.NewSandbox()
+
+			// cleanup
+			var c = make(chan os.Signal, 1)
+			signal.Notify(c, os.Interrupt)
+			go func() {
+				<-c
+				sandbox.Cleanup()
+				checkerSandbox.Cleanup()
+			}()
+
+			// Flag: log-level, log-format
+			if logLevel >= 0 {
+				logger, err := newLogger(logLevel, logFormat)
+				if err != nil {
+					return err
+				}
+				sandbox.WithLogger(logger)
+				checkerSandbox.WithLogger(logger.WithName("Checker"))
+			}
+
+			// Flag: policy-file
+			if err := loadPolicy(sandbox, policyFilePath, policy); err != nil {
+				return err
+			}
+
+			// Flag: checker, checker-prog, checker-arg, checker-policy-file, float-epsilon
+			var checker gsandbox.Checker
+			switch checkerName {
+			case gsandbox.CHECKER_EXACT:
+				checker = gsandbox.ExactChecker{}
+			case gsandbox.CHECKER_WHITESPACE:
+				checker = gsandbox.WhitespaceChecker{}
+			case gsandbox.CHECKER_FLOAT:
+				checker = gsandbox.FloatChecker{Epsilon: floatEpsilon}
+			case gsandbox.CHECKER_CUSTOM:
+				if checkerProg == "" {
+					return fmt.Errorf("invalid flags: --checker-prog is required by the custom checker")
+				}
+				if err := loadPolicy(checkerSandbox, checkerPolicyFilePath, "_default"); err != nil {
+					return err
+				}
+				checker = gsandbox.ProgramChecker{Sandbox: checkerSandbox, Prog: checkerProg, Args: checkerArgs}
+			default:
+				return fmt.Errorf("invalid checker: %s", checkerName)
+			}
+
+			// cases
+			cases, err := gsandbox.LoadJudgeCases(args[0])
+			if err != nil {
+				return err
+			}
+
+			// Flag: work-dir
+			var judge = gsandbox.NewJudge(sandbox, checker)
+			if workDir != "" {
+				judge.Dir = workDir
+			}
+
+			// run
+			var report = judge.Run(args[1], args[2:], cases)
+
+			// Flag: report-file
+			var reportData, _ = json.MarshalIndent(report, "", "  ")
+			if reportFilePath == "" {
+				fmt.Println(string(reportData))
+			} else if err := os.WriteFile(reportFilePath, reportData, 0644); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+
+	judgeCommand.DisableFlagsInUseLine = true
+	judgeCommand.Flags().StringVar(&policyFilePath, "policy-file", "", "use the specified policy configuration file")
+	judgeCommand.Flags().StringVar(&reportFilePath, "report-file", "", "generate a JSON-formatted report at the specified location, defaults to stdout")
+	judgeCommand.Flags().StringVar(&checkerName, "checker", gsandbox.CHECKER_EXACT, "compare the output with the answer by exact, whitespace, float or custom")
+	judgeCommand.Flags().StringVar(&checkerProg, "checker-prog", "", "the checker program used by the custom checker")
+	judgeCommand.Flags().StringArrayVar(&checkerArgs, "checker-arg", nil, "the argument passed to the checker program, can be specified multiple times")
+	judgeCommand.Flags().StringVar(&checkerPolicyFilePath, "checker-policy-file", "", "use the specified policy configuration file for the checker program")
+	judgeCommand.Flags().Float64Var(&floatEpsilon, "float-epsilon", 1e-6, "the absolute or relative error accepted by the float checker")
+	addLogFlags(judgeCommand, &logLevel, &logFormat)
+	judgeCommand.Flags().StringVar(&workDir, "work-dir", "", "run PROGRAM under the specified directory")
+
+	judgeCommand.Flags().StringVar(&policy, "policy", "_default", "use the specified policy")
+	_ = judgeCommand.Flags().MarkHidden("policy")
+
+	return judgeCommand
+}
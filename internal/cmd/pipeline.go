@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/souk4711/gsandbox"
+)
+
+func newPipelineCommand() *cobra.Command {
+	var reportFilePath string
+	var logLevel int
+	var logFormat string
+	var workspace string
+
+	var pipelineCommand = &cobra.Command{
+		Use:   "pipeline [flags] FILE",
+		Short: "Run the steps of a pipeline in sandboxes, e.g. compile then run",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var pipeline = gsandbox.NewPipeline().WithPolicyLoader(func(name string) ([]byte, error) {
+				return policiesFS.ReadFile(fmt.Sprintf("policies/%s.yml", name))
+			})
+
+			// cleanup
+			var c = make(chan os.Signal, 1)
+			signal.Notify(c, os.Interrupt)
+			go func() {
+				<-c
+				pipeline.Cleanup()
+			}()
+
+			// Flag: log-level, log-format
+			if logLevel >= 0 {
+				logger, err := newLogger(logLevel, logFormat)
+				if err != nil {
+					return err
+				}
+				pipeline.WithLogger(logger)
+			}
+
+			// spec
+			if err := pipeline.LoadFromFile(args[0]); err != nil {
+				return err
+			}
+
+			// Flag: workspace
+			if workspace != "" {
+				dir, err := filepath.Abs(workspace)
+				if err != nil {
+					return err
+				}
+				pipeline.Workspace = dir
+			}
+
+			// run
+			pipeline.Stdout = os.Stdout
+			pipeline.Stderr = os.Stderr
+			var report = pipeline.Run()
+
+			// Flag: report-file
+			var reportData, _ = json.MarshalIndent(report, "", "  ")
+			var _ = os.WriteFile(reportFilePath, reportData, 0644)
+			if !report.OK {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("pipeline: step(%s) failed: %s", report.FailedStep, report.Steps[len(report.Steps)-1].Reason)
+			}
+			return nil
+		},
+	}
+
+	pipelineCommand.DisableFlagsInUseLine = true
+	pipelineCommand.Flags().StringVar(&reportFilePath, "report-file", "", "generate a JSON-formatted report at the specified location")
+	addLogFlags(pipelineCommand, &logLevel, &logFormat)
+	pipelineCommand.Flags().StringVar(&workspace, "workspace", "", "use the specified directory as the workspace, which is kept after the run")
+
+	return pipelineCommand
+}
@@ -39,6 +39,9 @@ func newGsandboxCommand() *cobra.Command {
 	rootCommand.CompletionOptions.DisableDefaultCmd = true
 	rootCommand.AddCommand(newVersionCommand())
 	rootCommand.AddCommand(newRunCommand())
+	rootCommand.AddCommand(newJudgeCommand())
+	rootCommand.AddCommand(newPipelineCommand())
+	rootCommand.AddCommand(newServeCommand())
 
 	return rootCommand
 }
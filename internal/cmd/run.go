@@ -4,14 +4,20 @@ import (
 	"embed"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/logr/funcr"
 	"github.com/spf13/cobra"
 
 	"github.com/souk4711/gsandbox"
+	"github.com/souk4711/gsandbox/pkg/ptrace"
+	"github.com/souk4711/gsandbox/pkg/pty"
 )
 
 var (
@@ -19,11 +25,26 @@ var (
 	policiesFS embed.FS
 )
 
+const (
+	// the size of the head and the tail of stdout/stderr included in the report
+	capturedOutputHeadSize = 32 * 1024
+	capturedOutputTailSize = 32 * 1024
+)
+
 func newRunCommand() *cobra.Command {
 	var policyFilePath string
 	var reportFilePath string
-	var verbose bool
+	var reportDetail bool
+	var captureOutput bool
+	var traceFilePath string
+	var traceFormat string
+	var traceBuffer int
+	var logLevel int
+	var logFormat string
 	var workDir string
+	var stdinFilePath string
+	var stdinString string
+	var tty bool
 	var policy string
 
 	var runCommand = &cobra.Command{
@@ -41,28 +62,18 @@ func newRunCommand() *cobra.Command {
 				sandbox.Cleanup()
 			}()
 
-			// Flag: verbose
-			if verbose {
-				var logger logr.Logger = funcr.New(func(prefix, args string) {
-					fmt.Println(prefix, args)
-				}, funcr.Options{}).WithName("Gsandbox")
+			// Flag: log-level, log-format
+			if logLevel >= 0 {
+				logger, err := newLogger(logLevel, logFormat)
+				if err != nil {
+					return err
+				}
 				sandbox.WithLogger(logger)
 			}
 
 			// Flag: policy-file
-			if policyFilePath == "" {
-				data, err := policiesFS.ReadFile(fmt.Sprintf("policies/%s.yml", policy))
-				if err != nil {
-					return err
-				} else {
-					if err := sandbox.LoadPolicyFromData(data); err != nil {
-						return err
-					}
-				}
-			} else {
-				if err := sandbox.LoadPolicyFromFile(policyFilePath); err != nil {
-					return err
-				}
+			if err := loadPolicy(sandbox, policyFilePath, policy); err != nil {
+				return err
 			}
 
 			// Flag: work-dir
@@ -71,10 +82,58 @@ func newRunCommand() *cobra.Command {
 				executor.Dir = workDir
 			}
 
+			// Flag: stdin, stdin-string
+			var stdin io.Reader = os.Stdin
+			if stdinFilePath != "" && stdinString != "" {
+				return fmt.Errorf("invalid flags: --stdin and --stdin-string are mutually exclusive")
+			} else if stdinFilePath != "" {
+				stdinFile, err := os.Open(stdinFilePath)
+				if err != nil {
+					return err
+				}
+				defer stdinFile.Close()
+				stdin = stdinFile
+			} else if stdinString != "" {
+				stdin = strings.NewReader(stdinString)
+			}
+
+			// Flag: report-detail
+			if reportDetail {
+				executor.WithDetailedReport()
+			}
+
+			// Flag: capture-output
+			if captureOutput {
+				executor.WithCapturedOutput(capturedOutputHeadSize, capturedOutputTailSize)
+			}
+
+			// Flag: trace-file, trace-format, trace-buffer
+			if traceFilePath != "" {
+				if traceFormat != gsandbox.TRACE_FORMAT_JSON && traceFormat != gsandbox.TRACE_FORMAT_STRACE {
+					return fmt.Errorf("invalid trace format: %s", traceFormat)
+				}
+				if traceBuffer < 0 {
+					return fmt.Errorf("invalid trace buffer size: %d", traceBuffer)
+				}
+				traceFile, err := os.Create(traceFilePath)
+				if err != nil {
+					return err
+				}
+				defer traceFile.Close()
+				executor.WithTrace(traceFile, traceFormat).WithBufferPreview(traceBuffer)
+			}
+
 			// run
-			executor.Stdout = os.Stdout
-			executor.Stderr = os.Stderr
-			executor.Run()
+			if tty {
+				if err := runWithTTY(executor, stdin); err != nil {
+					return err
+				}
+			} else {
+				executor.Stdin = stdin
+				executor.Stdout = os.Stdout
+				executor.Stderr = os.Stderr
+				executor.Run()
+			}
 
 			// Flag: report-file
 			var resultData, _ = json.MarshalIndent(executor.Result, "", "  ")
@@ -86,11 +145,105 @@ func newRunCommand() *cobra.Command {
 	runCommand.DisableFlagsInUseLine = true
 	runCommand.Flags().StringVar(&policyFilePath, "policy-file", "", "use the specified policy configuration file")
 	runCommand.Flags().StringVar(&reportFilePath, "report-file", "", "generate a JSON-formatted report at the specified location")
-	runCommand.Flags().BoolVar(&verbose, "verbose", false, "turn on verbose mode")
+	runCommand.Flags().BoolVar(&reportDetail, "report-detail", false, "include syscall and I/O statistics in the report")
+	runCommand.Flags().BoolVar(&captureOutput, "capture-output", false, "include the head and tail of stdout/stderr in the report")
+	runCommand.Flags().StringVar(&traceFilePath, "trace-file", "", "write a syscall trace to the specified location")
+	runCommand.Flags().StringVar(&traceFormat, "trace-format", gsandbox.TRACE_FORMAT_JSON, "format of the syscall trace, json or strace")
+	runCommand.Flags().IntVar(&traceBuffer, "trace-buffer", ptrace.DEFAULT_MAX_BUFFER_PREVIEW, "max bytes of a buffer in the syscall trace, e.g. the data passed to write(2)")
+	addLogFlags(runCommand, &logLevel, &logFormat)
 	runCommand.Flags().StringVar(&workDir, "work-dir", "", "run PROGRAM under the specified directory")
+	runCommand.Flags().StringVar(&stdinFilePath, "stdin", "", "read the standard input of PROGRAM from the specified file")
+	runCommand.Flags().StringVar(&stdinString, "stdin-string", "", "use the specified string as the standard input of PROGRAM")
+	runCommand.Flags().BoolVar(&tty, "tty", false, "run PROGRAM in a pseudo-terminal, e.g. an interactive shell")
 
 	runCommand.Flags().StringVar(&policy, "policy", "_default", "use the specified policy")
 	_ = runCommand.Flags().MarkHidden("policy")
 
 	return runCommand
 }
+
+// newLogger returns the logger used by --log-level, plz see gsandbox.LOG_LEVEL_*
+func newLogger(level int, format string) (logr.Logger, error) {
+	var opts = funcr.Options{Verbosity: level}
+	switch format {
+	case "text":
+		return funcr.New(func(prefix, args string) {
+			fmt.Fprintln(os.Stderr, prefix, args)
+		}, opts).WithName("Gsandbox"), nil
+	case "json":
+		return funcr.NewJSON(func(obj string) {
+			fmt.Fprintln(os.Stderr, obj)
+		}, opts).WithName("Gsandbox"), nil
+	default:
+		return logr.Discard(), fmt.Errorf("invalid flags: --log-format must be json or text")
+	}
+}
+
+// addLogFlags adds the flags --log-level and --log-format
+func addLogFlags(cmd *cobra.Command, level *int, format *string) {
+	cmd.Flags().IntVar(level, "log-level", -1, "log at the verbosity level, 0 (results) to 3 (fd tracking), -1 to turn off")
+	cmd.Flags().StringVar(format, "log-format", "text", "log in the format, json or text")
+}
+
+// loadPolicy loads the policy from the file if specified, otherwise loads the builtin one
+func loadPolicy(sandbox *gsandbox.Sandbox, policyFilePath string, policy string) error {
+	if policyFilePath != "" {
+		return sandbox.LoadPolicyFromFile(policyFilePath)
+	}
+
+	data, err := policiesFS.ReadFile(fmt.Sprintf("policies/%s.yml", policy))
+	if err != nil {
+		return err
+	}
+	return sandbox.LoadPolicyFromData(data)
+}
+
+// runWithTTY runs the executor in a pseudo-terminal, the terminal of the cli is put into raw mode and its window
+// size is forwarded, so the keys, e.g. Ctrl-C, are handled by the pseudo-terminal
+func runWithTTY(executor *gsandbox.Executor, stdin io.Reader) error {
+	ptmx, tty, err := pty.Open()
+	if err != nil {
+		return err
+	}
+	defer ptmx.Close()
+
+	if stdin == os.Stdin && pty.IsTerminal(os.Stdin) {
+		_ = pty.InheritSize(os.Stdin, tty)
+		var c = make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGWINCH)
+		defer signal.Stop(c)
+		go func() {
+			for range c {
+				_ = pty.InheritSize(os.Stdin, tty)
+			}
+		}()
+
+		state, err := pty.MakeRaw(os.Stdin)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = pty.Restore(os.Stdin, state)
+		}()
+	}
+
+	// copy the input and the output
+	var done = make(chan struct{})
+	go func() {
+		_, _ = io.Copy(ptmx, stdin)
+		_, _ = ptmx.Write([]byte{4}) // EOF, i.e. Ctrl-D
+	}()
+	go func() {
+		_, _ = io.Copy(os.Stdout, ptmx) // EIO once all of the tty fds are closed
+		close(done)
+	}()
+
+	executor.WithTTY(tty)
+	executor.Run()
+	tty.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+	}
+	return nil
+}
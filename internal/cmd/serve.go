@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/souk4711/gsandbox"
+)
+
+func newServeCommand() *cobra.Command {
+	var listen string
+	var maxConcurrency int
+	var maxQueue int
+	var metrics bool
+	var allowInlinePolicy bool
+	var logLevel int
+	var logFormat string
+
+	var serveCommand = &cobra.Command{
+		Use:   "serve [flags]",
+		Short: "Run programs in sandboxes submitted via an HTTP/JSON API",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if maxConcurrency < 1 {
+				return fmt.Errorf("invalid flags: --max-concurrency must be positive")
+			}
+			var server = gsandbox.NewServer(maxConcurrency, maxQueue).WithPolicyLoader(func(name string) ([]byte, error) {
+				return policiesFS.ReadFile(fmt.Sprintf("policies/%s.yml", name))
+			})
+
+			// Flag: allow-inline-policy, the clients of the unix socket are trusted by the file permission
+			server.AllowInlinePolicy = allowInlinePolicy || strings.HasPrefix(listen, "unix:")
+
+			// Flag: metrics
+			if metrics {
+				server.WithMetrics(gsandbox.NewMetrics())
+			}
+
+			// Flag: log-level, log-format
+			if logLevel >= 0 {
+				logger, err := newLogger(logLevel, logFormat)
+				if err != nil {
+					return err
+				}
+				server.WithLogger(logger)
+			}
+
+			// Flag: listen
+			listener, err := newListener(listen)
+			if err != nil {
+				return err
+			}
+
+			// shutdown
+			var httpServer = &http.Server{Handler: server}
+			var c = make(chan os.Signal, 1)
+			signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+			go func() {
+				<-c
+				server.Shutdown()
+				var ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = httpServer.Shutdown(ctx)
+			}()
+
+			// serve
+			cmd.SilenceUsage = true
+			fmt.Fprintf(os.Stderr, "gsandbox: listening on %s\n", listen)
+			if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+
+	serveCommand.DisableFlagsInUseLine = true
+	serveCommand.Flags().StringVar(&listen, "listen", "unix:/run/gsandbox.sock", "listen on the address, unix:PATH or tcp:HOST:PORT")
+	serveCommand.Flags().IntVar(&maxConcurrency, "max-concurrency", runtime.NumCPU(), "run at most the number of programs at the same time")
+	serveCommand.Flags().IntVar(&maxQueue, "max-queue", 64, "queue at most the number of programs, the submission is rejected once exceeded")
+	serveCommand.Flags().BoolVar(&allowInlinePolicy, "allow-inline-policy", false, "accept policyData and limits in the submission on a tcp: listener")
+	serveCommand.Flags().BoolVar(&metrics, "metrics", false, "export the metrics in the Prometheus text format at /metrics")
+	addLogFlags(serveCommand, &logLevel, &logFormat)
+
+	return serveCommand
+}
+
+// newListener listens on unix:PATH or tcp:HOST:PORT, the stale unix socket file is removed
+func newListener(address string) (net.Listener, error) {
+	switch {
+	case strings.HasPrefix(address, "unix:"):
+		var path = strings.TrimPrefix(address, "unix:")
+		if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+			_ = os.Remove(path)
+		}
+		return net.Listen("unix", path)
+	case strings.HasPrefix(address, "tcp:"):
+		return net.Listen("tcp", strings.TrimPrefix(address, "tcp:"))
+	default:
+		return nil, fmt.Errorf("invalid listen address: %s", address)
+	}
+}
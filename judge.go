@@ -0,0 +1,182 @@
+package gsandbox
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// verdicts
+const (
+	VERDICT_ACCEPTED              = "AC"  // the output is accepted by the checker
+	VERDICT_WRONG_ANSWER          = "WA"  // the output is rejected by the checker
+	VERDICT_TIME_LIMIT_EXCEEDED   = "TLE" // StatusTimeLimitExceeded
+	VERDICT_MEMORY_LIMIT_EXCEEDED = "MLE" // StatusMemoryLimitExceeded
+	VERDICT_OUTPUT_LIMIT_EXCEEDED = "OLE" // StatusOutputLimitExceeded
+	VERDICT_RESTRICTED_FUNCTION   = "RF"  // StatusViolation, StatusSyscallLimitExceeded
+	VERDICT_RUNTIME_ERROR         = "RE"  // exit with nonzero code, or terminated with a signal
+	VERDICT_SYSTEM_ERROR          = "SE"  // StatusSandboxFailure, StatusExitFailure, or the checker fails
+)
+
+const (
+	// the stdout limit of a case, if the policy does not specify one
+	judgeMaxOutput = 64 * 1024 * 1024
+)
+
+// JudgeCase is a pair of input and answer files, e.g. 1.in and 1.out
+type JudgeCase struct {
+	Name       string `json:"name"`
+	InputFile  string `json:"inputFile"`
+	AnswerFile string `json:"answerFile"`
+}
+
+type JudgeCaseResult struct {
+	Name    string `json:"name"`
+	Verdict string `json:"verdict"`
+	Message string `json:"message,omitempty"` // the message of the checker, or the reason of the status
+	Result  Result `json:"result"`
+}
+
+type JudgeReport struct {
+	Verdict     string            `json:"verdict"`     // the verdict of the first failed case, or AC
+	Passed      int               `json:"passed"`      // number of accepted cases
+	Total       int               `json:"total"`       // number of cases
+	MaxRealTime time.Duration     `json:"maxRealTime"` // maximum wall time used by a case
+	MaxMaxrss   int64             `json:"maxMaxrss"`   // maximum resident set size used by a case (in kilobytes)
+	Cases       []JudgeCaseResult `json:"cases"`
+}
+
+// Judge runs a program against judge cases, each case runs in a new executor of the sandbox, so the
+// limits of the policy apply to each case
+type Judge struct {
+	Dir string // working directory of the program, the work-dir of the policy if empty, plz see Executor.Dir
+
+	sandbox *Sandbox
+	checker Checker
+}
+
+func NewJudge(sandbox *Sandbox, checker Checker) *Judge {
+	return &Judge{sandbox: sandbox, checker: checker}
+}
+
+// LoadJudgeCases finds the N.in/N.out pairs in the directory, sorted by N, numerically if possible
+func LoadJudgeCases(dir string) ([]JudgeCase, error) {
+	inputFiles, err := filepath.Glob(filepath.Join(dir, "*.in"))
+	if err != nil {
+		return nil, err
+	}
+
+	var cases = make([]JudgeCase, 0, len(inputFiles))
+	for _, inputFile := range inputFiles {
+		var name = strings.TrimSuffix(filepath.Base(inputFile), ".in")
+		var answerFile = strings.TrimSuffix(inputFile, ".in") + ".out"
+		if _, err := os.Stat(answerFile); err != nil {
+			return nil, fmt.Errorf("judge: case(%s): %s", name, err.Error())
+		}
+		cases = append(cases, JudgeCase{Name: name, InputFile: inputFile, AnswerFile: answerFile})
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("judge: no cases found in %s", dir)
+	}
+
+	sort.Slice(cases, func(i, j int) bool {
+		x, errX := strconv.Atoi(cases[i].Name)
+		y, errY := strconv.Atoi(cases[j].Name)
+		if errX == nil && errY == nil {
+			return x < y
+		}
+		return cases[i].Name < cases[j].Name
+	})
+	return cases, nil
+}
+
+// Run runs the program against the cases in order
+func (j *Judge) Run(prog string, args []string, cases []JudgeCase) *JudgeReport {
+	var report = &JudgeReport{Verdict: VERDICT_ACCEPTED, Total: len(cases), Cases: make([]JudgeCaseResult, 0, len(cases))}
+	for _, c := range cases {
+		var r = j.RunCase(prog, args, c)
+		report.Cases = append(report.Cases, r)
+		if r.Verdict == VERDICT_ACCEPTED {
+			report.Passed += 1
+		} else if report.Verdict == VERDICT_ACCEPTED {
+			report.Verdict = r.Verdict
+		}
+		if r.Result.RealTime > report.MaxRealTime {
+			report.MaxRealTime = r.Result.RealTime
+		}
+		if r.Result.Maxrss > report.MaxMaxrss {
+			report.MaxMaxrss = r.Result.Maxrss
+		}
+	}
+	return report
+}
+
+// RunCase runs the program with the input file as stdin, and checks its stdout
+func (j *Judge) RunCase(prog string, args []string, c JudgeCase) JudgeCaseResult {
+	var cr = JudgeCaseResult{Name: c.Name}
+
+	input, err := os.Open(c.InputFile)
+	if err != nil {
+		cr.Verdict, cr.Message = VERDICT_SYSTEM_ERROR, err.Error()
+		return cr
+	}
+	defer input.Close()
+
+	var output bytes.Buffer
+	var executor = j.sandbox.NewExecutor(prog, args)
+	if executor.limits.LimitStdout == nil {
+		var lim uint64 = judgeMaxOutput
+		executor.limits.LimitStdout = &lim
+	}
+	if j.Dir != "" { // otherwise the work-dir of the policy
+		executor.Dir = j.Dir
+	}
+	executor.Stdin = input
+	executor.Stdout = &output
+	executor.Run()
+
+	var r = executor.Result
+	cr.Result = r
+	cr.Message = r.Reason
+	switch r.Status {
+	case StatusOK:
+		if r.ExitCode != 0 {
+			cr.Verdict, cr.Message = VERDICT_RUNTIME_ERROR, fmt.Sprintf("exit with code %d", r.ExitCode)
+			return cr
+		}
+	case StatusTimeLimitExceeded:
+		cr.Verdict = VERDICT_TIME_LIMIT_EXCEEDED
+		return cr
+	case StatusMemoryLimitExceeded:
+		cr.Verdict = VERDICT_MEMORY_LIMIT_EXCEEDED
+		return cr
+	case StatusOutputLimitExceeded:
+		cr.Verdict = VERDICT_OUTPUT_LIMIT_EXCEEDED
+		return cr
+	case StatusViolation, StatusSyscallLimitExceeded:
+		cr.Verdict = VERDICT_RESTRICTED_FUNCTION
+		return cr
+	case StatusSignaled:
+		cr.Verdict = VERDICT_RUNTIME_ERROR
+		return cr
+	default:
+		cr.Verdict = VERDICT_SYSTEM_ERROR
+		return cr
+	}
+
+	ok, message, err := j.checker.Check(c, output.Bytes())
+	switch {
+	case err != nil:
+		cr.Verdict, cr.Message = VERDICT_SYSTEM_ERROR, err.Error()
+	case ok:
+		cr.Verdict, cr.Message = VERDICT_ACCEPTED, message
+	default:
+		cr.Verdict, cr.Message = VERDICT_WRONG_ANSWER, message
+	}
+	return cr
+}
@@ -7,4 +7,16 @@ type Limits struct {
 	RlimitFSIZE        *uint64
 	RlimitNOFILE       *uint64
 	LimitWallClockTime *uint64
+	LimitExecCount     *uint64
+	LimitExecDepth     *uint64
+	LimitProcessCount  *uint64                 // processes and threads created
+	LimitFileCount     *uint64                 // files opened
+	LimitSyscalls      map[string]SyscallLimit // per-syscall limits
+	LimitStdout        *uint64                 // bytes written to stdout
+	LimitStderr        *uint64                 // bytes written to stderr
+}
+
+type SyscallLimit struct {
+	Count *uint64 // calls in total
+	Rate  *uint64 // calls per second
 }
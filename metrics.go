@@ -0,0 +1,248 @@
+package gsandbox
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/souk4711/gsandbox/pkg/fsfilter"
+)
+
+// tracer stops
+const (
+	TRACER_STOP_SYSCALL_ENTER = "syscall-enter" // syscall-enter-stop
+	TRACER_STOP_SYSCALL_LEAVE = "syscall-leave" // syscall-exit-stop
+	TRACER_STOP_NEW_CHILD     = "new-child"     // PTRACE_EVENT_FORK, PTRACE_EVENT_VFORK, PTRACE_EVENT_CLONE
+	TRACER_STOP_EXEC          = "exec"          // PTRACE_EVENT_EXEC
+	TRACER_STOP_EXIT          = "exit"          // a tracee exits or is terminated with a signal
+)
+
+var tracerStopKinds = []string{
+	TRACER_STOP_SYSCALL_ENTER,
+	TRACER_STOP_SYSCALL_LEAVE,
+	TRACER_STOP_NEW_CHILD,
+	TRACER_STOP_EXEC,
+	TRACER_STOP_EXIT,
+}
+
+// MetricsHook receives the events of the executors, plz see Sandbox#WithMetrics and Executor#WithMetrics.
+// The executors may run concurrently, so the hook must be safe for concurrent use.
+type MetricsHook interface {
+	// ExecutorStarted is called once Executor#Run is called
+	ExecutorStarted()
+
+	// ExecutorFinished is called once Executor#Run returns
+	ExecutorFinished(r *Result)
+
+	// Violation is called once a syscall is denied, perm is the file permission required, one of
+	// "read", "write", "execute", or empty if it's not a file access violation
+	Violation(syscall string, perm string)
+
+	// TracerStop is called on each stop of the tracees handled by the tracer, plz see TRACER_STOP_*
+	TracerStop(kind string)
+}
+
+// the upper bounds of the buckets of the time histograms, in seconds
+var metricsTimeBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// Metrics is a MetricsHook which keeps the metrics in memory, and exports them in the Prometheus text format
+type Metrics struct {
+	tracerStops []uint64 // counts of each kind of tracerStopKinds, updated atomically without mu on the hot path
+	runs        map[Status]uint64
+	times       map[string]*metricsHistogram // real, user, system
+	violations  map[[2]string]uint64         // syscall, perm
+	active      int64
+	mu          sync.Mutex
+}
+
+type metricsHistogram struct {
+	buckets []uint64 // counts of each bucket, not cumulative
+	count   uint64
+	sum     float64
+}
+
+func NewMetrics() *Metrics {
+	var m = Metrics{
+		runs:        make(map[Status]uint64),
+		times:       make(map[string]*metricsHistogram),
+		violations:  make(map[[2]string]uint64),
+		tracerStops: make([]uint64, len(tracerStopKinds)),
+	}
+	for _, name := range []string{"real", "user", "system"} {
+		m.times[name] = &metricsHistogram{buckets: make([]uint64, len(metricsTimeBuckets))}
+	}
+	return &m
+}
+
+func (m *Metrics) ExecutorStarted() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.active += 1
+}
+
+func (m *Metrics) ExecutorFinished(r *Result) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.active -= 1
+	m.runs[r.Status] += 1
+	m.times["real"].observe(r.RealTime)
+	m.times["user"].observe(r.UserTime)
+	m.times["system"].observe(r.SystemTime)
+}
+
+func (m *Metrics) Violation(syscall string, perm string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.violations[[2]string{syscall, perm}] += 1
+}
+
+// TracerStop counts the stop, the kinds other than TRACER_STOP_* are ignored
+func (m *Metrics) TracerStop(kind string) {
+	for i, k := range tracerStopKinds {
+		if k == kind {
+			atomic.AddUint64(&m.tracerStops[i], 1)
+			return
+		}
+	}
+}
+
+func (h *metricsHistogram) observe(d time.Duration) {
+	var v = d.Seconds()
+	h.count += 1
+	h.sum += v
+	for i, le := range metricsTimeBuckets {
+		if v <= le {
+			h.buckets[i] += 1
+			break
+		}
+	}
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var bw = bufio.NewWriter(w)
+
+	// runs
+	writeMetricsHeader(bw, "gsandbox_runs_total", "counter", "Number of finished runs, by status.")
+	var statuses = make([]Status, 0, len(m.runs))
+	for status := range m.runs {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+	for _, status := range statuses {
+		fmt.Fprintf(bw, "gsandbox_runs_total{status=%s} %d\n", quoteMetricsLabel(statusMetricsLabel(status)), m.runs[status])
+	}
+
+	// times
+	writeMetricsHeader(bw, "gsandbox_run_seconds", "histogram", "Time used by the finished runs, by real, user and system time.")
+	for _, name := range []string{"real", "user", "system"} {
+		var h, cumulative = m.times[name], uint64(0)
+		for i, le := range metricsTimeBuckets {
+			cumulative += h.buckets[i]
+			fmt.Fprintf(bw, "gsandbox_run_seconds_bucket{time=%q,le=%q} %d\n", name, strconv.FormatFloat(le, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(bw, "gsandbox_run_seconds_bucket{time=%q,le=\"+Inf\"} %d\n", name, h.count)
+		fmt.Fprintf(bw, "gsandbox_run_seconds_sum{time=%q} %s\n", name, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(bw, "gsandbox_run_seconds_count{time=%q} %d\n", name, h.count)
+	}
+
+	// violations
+	writeMetricsHeader(bw, "gsandbox_violations_total", "counter", "Number of denied syscalls, by syscall name and file permission required.")
+	var violations = make([][2]string, 0, len(m.violations))
+	for key := range m.violations {
+		violations = append(violations, key)
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i][0] != violations[j][0] {
+			return violations[i][0] < violations[j][0]
+		}
+		return violations[i][1] < violations[j][1]
+	})
+	for _, key := range violations {
+		fmt.Fprintf(bw, "gsandbox_violations_total{syscall=%s,perm=%s} %d\n", quoteMetricsLabel(key[0]), quoteMetricsLabel(key[1]), m.violations[key])
+	}
+
+	// tracer stops
+	writeMetricsHeader(bw, "gsandbox_tracer_stops_total", "counter", "Number of tracee stops handled by the tracer, by kind.")
+	for i, kind := range tracerStopKinds {
+		fmt.Fprintf(bw, "gsandbox_tracer_stops_total{kind=%s} %d\n", quoteMetricsLabel(kind), atomic.LoadUint64(&m.tracerStops[i]))
+	}
+
+	// active
+	writeMetricsHeader(bw, "gsandbox_executors_active", "gauge", "Number of running executors.")
+	fmt.Fprintf(bw, "gsandbox_executors_active %d\n", m.active)
+
+	return bw.Flush()
+}
+
+// ServeHTTP exports the metrics, e.g. as the /metrics endpoint
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
+
+func writeMetricsHeader(w io.Writer, name string, typ string, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, typ)
+}
+
+// quoteMetricsLabel quotes the label value, only backslash, double-quote and line feed are escaped
+func quoteMetricsLabel(v string) string {
+	var r = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(v) + `"`
+}
+
+// statusMetricsLabel returns the label value of the status, e.g. time_limit_exceeded
+func statusMetricsLabel(status Status) string {
+	switch status {
+	case StatusUnset:
+		return "unset"
+	case StatusOK:
+		return "ok"
+	case StatusSandboxFailure:
+		return "sandbox_failure"
+	case StatusTimeLimitExceeded:
+		return "time_limit_exceeded"
+	case StatusMemoryLimitExceeded:
+		return "memory_limit_exceeded"
+	case StatusOutputLimitExceeded:
+		return "output_limit_exceeded"
+	case StatusViolation:
+		return "violation"
+	case StatusSignaled:
+		return "signaled"
+	case StatusExitFailure:
+		return "exit_failure"
+	case StatusSyscallLimitExceeded:
+		return "syscall_limit_exceeded"
+	default:
+		return strconv.Itoa(int(status))
+	}
+}
+
+// fsPermMetricsLabel returns the label value of the file permission, plz see MetricsHook#Violation
+func fsPermMetricsLabel(perm int) string {
+	switch perm {
+	case fsfilter.FILE_RD:
+		return "read"
+	case fsfilter.FILE_WR:
+		return "write"
+	case fsfilter.FILE_EX:
+		return "execute"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,39 @@
+package gsandbox
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/souk4711/gsandbox/pkg/fsfilter"
+)
+
+func TestMetricsTracerStopConcurrent(t *testing.T) {
+	var m = NewMetrics()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				m.TracerStop(TRACER_STOP_SYSCALL_ENTER)
+			}
+		}()
+	}
+	wg.Wait()
+	m.Violation("openat", fsPermMetricsLabel(fsfilter.FILE_WR))
+
+	var b strings.Builder
+	if err := m.WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		`gsandbox_tracer_stops_total{kind="syscall-enter"} 8000`,
+		`gsandbox_tracer_stops_total{kind="exec"} 0`,
+		`gsandbox_violations_total{syscall="openat",perm="write"} 1`,
+	} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("missing: %s", line)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package gsandbox
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"io"
+	"sync"
+	"unicode/utf8"
+)
+
+// output encodings
+const (
+	OUTPUT_ENCODING_TEXT   = "text"   // valid UTF-8
+	OUTPUT_ENCODING_BASE64 = "base64" // binary
+)
+
+// CapturedOutput is the output written to stdout or stderr, plz see Executor#WithCapturedOutput
+type CapturedOutput struct {
+	Encoding string `json:"encoding"`       // text, or base64 if the output is not valid UTF-8 or contains NUL
+	Head     string `json:"head"`           // the first bytes, or the whole output if nothing is omitted
+	Tail     string `json:"tail,omitempty"` // the last bytes, if some bytes are omitted
+	Omitted  uint64 `json:"omitted"`        // bytes omitted between the head and the tail
+	Size     uint64 `json:"size"`           // bytes written, including the truncated ones
+	SHA256   string `json:"sha256"`         // hex-encoded SHA-256 digest of the whole output
+}
+
+// outputWriter forwards the output of the command to w, the bytes beyond the limit are discarded.
+//
+// The command is killed by the tracer once the limit is exceeded, unless FLAG_TRUNCATE_OUTPUT
+// is enabled, but the bytes of the last write(2) are already in the pipe.
+type outputWriter struct {
+	w     io.Writer
+	limit uint64
+	n     uint64 // bytes forwarded
+}
+
+func newOutputWriter(w io.Writer, limit *uint64) io.Writer {
+	if w == nil || limit == nil {
+		return w
+	}
+	return &outputWriter{w: w, limit: *limit}
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	if w.n < w.limit {
+		var chunk = p
+		if uint64(len(chunk)) > w.limit-w.n {
+			chunk = chunk[:w.limit-w.n]
+		}
+		n, err := w.w.Write(chunk)
+		w.n += uint64(n)
+		if err != nil {
+			return n, err
+		}
+	}
+	return len(p), nil // keep draining the pipe, so the command never blocks on write(2)
+}
+
+// outputCapture keeps the first and the last bytes of the output in memory, and hashes the whole output
+type outputCapture struct {
+	mu      sync.Mutex
+	head    []byte
+	tail    []byte
+	headMax int
+	tailMax int
+	size    uint64
+	digest  hash.Hash
+}
+
+func newOutputCapture(head int, tail int) *outputCapture {
+	return &outputCapture{headMax: head, tailMax: tail, digest: sha256.New()}
+}
+
+// teeOutput writes the output to both w and the capture, if any
+func teeOutput(w io.Writer, c *outputCapture) io.Writer {
+	switch {
+	case c == nil:
+		return w
+	case w == nil:
+		return c
+	default:
+		return io.MultiWriter(c, w)
+	}
+}
+
+func (c *outputCapture) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var n = len(p)
+	c.size += uint64(n)
+	c.digest.Write(p)
+
+	// head
+	if room := c.headMax - len(c.head); room > 0 {
+		if room > len(p) {
+			room = len(p)
+		}
+		c.head = append(c.head, p[:room]...)
+		p = p[room:]
+	}
+
+	// tail, keeps the last bytes only
+	if len(p) >= c.tailMax {
+		c.tail = append(c.tail[:0], p[len(p)-c.tailMax:]...)
+	} else {
+		c.tail = append(c.tail, p...)
+		if len(c.tail) > c.tailMax {
+			c.tail = c.tail[len(c.tail)-c.tailMax:]
+		}
+	}
+	return n, nil
+}
+
+func (c *outputCapture) result() *CapturedOutput {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var out = &CapturedOutput{Size: c.size, SHA256: hex.EncodeToString(c.digest.Sum(nil))}
+	var head, tail = c.head, c.tail
+	out.Omitted = c.size - uint64(len(head)) - uint64(len(tail))
+	if out.Omitted == 0 {
+		head, tail = append(append([]byte{}, head...), tail...), nil
+	}
+
+	// a multi-byte character may be split at where the bytes are omitted
+	var textHead, textTail = head, tail
+	if out.Omitted != 0 {
+		textHead, textTail = trimIncompleteRuneSuffix(head), trimIncompleteRunePrefix(tail)
+	}
+	if isText(textHead) && isText(textTail) {
+		out.Encoding = OUTPUT_ENCODING_TEXT
+		out.Head, out.Tail = string(textHead), string(textTail)
+		out.Omitted += uint64(len(head) - len(textHead) + len(tail) - len(textTail))
+	} else {
+		out.Encoding = OUTPUT_ENCODING_BASE64
+		out.Head, out.Tail = base64.StdEncoding.EncodeToString(head), base64.StdEncoding.EncodeToString(tail)
+	}
+	return out
+}
+
+func isText(b []byte) bool {
+	return utf8.Valid(b) && bytes.IndexByte(b, 0) == -1
+}
+
+func trimIncompleteRuneSuffix(b []byte) []byte {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if !utf8.FullRune(b[i:]) {
+				return b[:i]
+			}
+			break
+		}
+	}
+	return b
+}
+
+func trimIncompleteRunePrefix(b []byte) []byte {
+	var i = 0
+	for i < len(b) && i < utf8.UTFMax-1 && !utf8.RuneStart(b[i]) {
+		i++
+	}
+	return b[i:]
+}
@@ -0,0 +1,323 @@
+package gsandbox
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"gopkg.in/yaml.v3"
+
+	"github.com/souk4711/gsandbox/pkg/fsfilter"
+)
+
+// Pipeline runs the steps in order in a shared workspace directory, e.g. compiles a program with g++ and then
+// runs the produced binary, each step with its own policy. It stops at the first failing step.
+type Pipeline struct {
+	Workspace string         `yaml:"workspace"` // a temporary directory is used if empty, relative to the spec file
+	Steps     []PipelineStep `yaml:"steps"`
+
+	// Stdout, Stderr receive the output of the steps which do not redirect it into the workspace
+	Stdout io.Writer `yaml:"-"`
+	Stderr io.Writer `yaml:"-"`
+
+	dir          string // directory of the spec file
+	logger       logr.Logger
+	policyLoader func(name string) ([]byte, error)
+	mu           sync.Mutex // guards sandbox and canceled, Cleanup may be called from another goroutine
+	sandbox      *Sandbox   // sandbox of the running step
+	canceled     bool       // the steps which have not run are skipped
+}
+
+// errPipelineCanceled is the reason of the step skipped by Pipeline#Cleanup
+var errPipelineCanceled = errors.New("pipeline: canceled")
+
+type PipelineStep struct {
+	Name       string       `yaml:"name"`
+	Policy     string       `yaml:"policy"`      // name of a policy, plz see Pipeline#WithPolicyLoader
+	PolicyFile string       `yaml:"policy-file"` // policy configuration file, relative to the spec file
+	Limits     PolicyLimits `yaml:"limits"`      // overrides the limits of the policy
+	Prog       string       `yaml:"prog"`
+	Args       []string     `yaml:"args"`
+	Stdin      string       `yaml:"stdin"`   // read the standard input from the file, relative to the workspace
+	Stdout     string       `yaml:"stdout"`  // write the standard output to the file, relative to the workspace
+	Stderr     string       `yaml:"stderr"`  // write the standard error to the file, relative to the workspace
+	Inputs     []string     `yaml:"inputs"`  // files copied into the workspace before the step runs, relative to the spec file
+	Outputs    []string     `yaml:"outputs"` // files the step must produce, relative to the workspace
+}
+
+type PipelineStepResult struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Reason string `json:"reason,omitempty"` // why the step fails
+	Result Result `json:"result"`
+}
+
+type PipelineReport struct {
+	OK         bool                 `json:"ok"`
+	FailedStep string               `json:"failedStep,omitempty"`
+	Workspace  string               `json:"workspace"`
+	Steps      []PipelineStepResult `json:"steps"` // results of the steps which have run
+}
+
+func NewPipeline() *Pipeline {
+	var p = Pipeline{
+		logger: funcr.New(func(_, _ string) {}, funcr.Options{}),
+		policyLoader: func(name string) ([]byte, error) {
+			return nil, fmt.Errorf("policy(%s) not found", name)
+		},
+	}
+	return &p
+}
+
+func (p *Pipeline) WithLogger(logger logr.Logger) *Pipeline {
+	p.logger = logger
+	return p
+}
+
+// WithPolicyLoader resolves the `policy` of the steps, e.g. the builtin policies of the cli
+func (p *Pipeline) WithPolicyLoader(loader func(name string) ([]byte, error)) *Pipeline {
+	p.policyLoader = loader
+	return p
+}
+
+func (p *Pipeline) LoadFromFile(filePath string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	if err := p.LoadFromData(data); err != nil {
+		return err
+	}
+	p.dir = filepath.Dir(filePath)
+	return nil
+}
+
+func (p *Pipeline) LoadFromData(data []byte) error {
+	if err := yaml.Unmarshal(data, p); err != nil {
+		return err
+	}
+	if len(p.Steps) == 0 {
+		return fmt.Errorf("pipeline: no steps")
+	}
+	var names = make(map[string]struct{})
+	for i, step := range p.Steps {
+		if step.Name == "" {
+			return fmt.Errorf("pipeline: step(%d): name is required", i+1)
+		}
+		if _, ok := names[step.Name]; ok {
+			return fmt.Errorf("pipeline: step(%s): duplicate name", step.Name)
+		}
+		names[step.Name] = struct{}{}
+		if step.Prog == "" {
+			return fmt.Errorf("pipeline: step(%s): prog is required", step.Name)
+		}
+		if (step.Policy == "") == (step.PolicyFile == "") {
+			return fmt.Errorf("pipeline: step(%s): exactly one of policy and policy-file is required", step.Name)
+		}
+	}
+	return nil
+}
+
+// Cleanup kills the running step and skips the rest, plz see Sandbox#Cleanup
+func (p *Pipeline) Cleanup() {
+	p.mu.Lock()
+	p.canceled = true
+	var s = p.sandbox
+	p.mu.Unlock()
+
+	if s != nil {
+		s.Cleanup()
+	}
+}
+
+// setSandbox sets the sandbox of the running step, returns false if the pipeline is canceled
+func (p *Pipeline) setSandbox(s *Sandbox) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.canceled && s != nil {
+		return false
+	}
+	p.sandbox = s
+	return true
+}
+
+func (p *Pipeline) isCanceled() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.canceled
+}
+
+// Run runs the steps in order, the temporary workspace is removed once all of the steps finish
+func (p *Pipeline) Run() *PipelineReport {
+	var report = &PipelineReport{OK: true, Steps: make([]PipelineStepResult, 0, len(p.Steps))}
+
+	var workspace = p.Workspace
+	if workspace == "" {
+		dir, err := os.MkdirTemp("", "gsandbox-pipeline-")
+		if err != nil {
+			report.OK, report.FailedStep = false, p.Steps[0].Name
+			report.Steps = append(report.Steps, p.newFailedStepResult(p.Steps[0], err))
+			return report
+		}
+		defer os.RemoveAll(dir)
+		workspace = dir
+	} else {
+		workspace = p.resolve(workspace)
+		if err := os.MkdirAll(workspace, 0755); err != nil {
+			report.OK, report.FailedStep = false, p.Steps[0].Name
+			report.Steps = append(report.Steps, p.newFailedStepResult(p.Steps[0], err))
+			return report
+		}
+	}
+	if v, err := filepath.Abs(workspace); err == nil {
+		workspace = v
+	}
+	report.Workspace = workspace
+
+	for _, step := range p.Steps {
+		var sr PipelineStepResult
+		if p.isCanceled() {
+			sr = p.newFailedStepResult(step, errPipelineCanceled)
+		} else {
+			p.logger.V(LOG_LEVEL_RESULT).Info("pipeline: Step", "step", step.Name, "prog", step.Prog)
+			sr = p.runStep(step, workspace)
+		}
+		report.Steps = append(report.Steps, sr)
+		if !sr.OK {
+			p.logger.V(LOG_LEVEL_RESULT).Info("pipeline: StepFailed", "step", step.Name, "reason", sr.Reason)
+			report.OK, report.FailedStep = false, step.Name
+			break
+		}
+	}
+	return report
+}
+
+func (p *Pipeline) runStep(step PipelineStep, workspace string) PipelineStepResult {
+	// policy
+	var sandbox = NewSandbox().WithLogger(p.logger.WithName(step.Name))
+	if step.PolicyFile != "" {
+		if err := sandbox.LoadPolicyFromFile(p.resolve(step.PolicyFile)); err != nil {
+			return p.newFailedStepResult(step, err)
+		}
+	} else {
+		data, err := p.policyLoader(step.Policy)
+		if err != nil {
+			return p.newFailedStepResult(step, err)
+		}
+		if err := sandbox.LoadPolicyFromData(data); err != nil {
+			return p.newFailedStepResult(step, err)
+		}
+	}
+	sandbox.policy.Limits.merge(step.Limits)
+
+	// inputs
+	for _, input := range step.Inputs {
+		if err := copyFile(p.resolve(input), filepath.Join(workspace, filepath.Base(input))); err != nil {
+			return p.newFailedStepResult(step, err)
+		}
+	}
+
+	// executor, the workspace is readable and writable
+	var executor = sandbox.NewExecutor(step.Prog, step.Args)
+	executor.Dir = workspace
+	executor.SetFilterFileList(fsfilter.FILE_RD, append(append([]string{}, sandbox.policy.FileSystem.ReadableFiles...), workspace+"/"))
+	executor.SetFilterFileList(fsfilter.FILE_WR, append(append([]string{}, sandbox.policy.FileSystem.WritableFiles...), workspace+"/"))
+
+	// stdin, stdout, stderr
+	var files = make([]*os.File, 0, 3)
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	executor.Stdout, executor.Stderr = p.Stdout, p.Stderr
+	if step.Stdin != "" {
+		f, err := os.Open(filepath.Join(workspace, step.Stdin))
+		if err != nil {
+			return p.newFailedStepResult(step, err)
+		}
+		files = append(files, f)
+		executor.Stdin = f
+	}
+	if step.Stdout != "" {
+		f, err := os.Create(filepath.Join(workspace, step.Stdout))
+		if err != nil {
+			return p.newFailedStepResult(step, err)
+		}
+		files = append(files, f)
+		executor.Stdout = f
+	}
+	if step.Stderr != "" {
+		f, err := os.Create(filepath.Join(workspace, step.Stderr))
+		if err != nil {
+			return p.newFailedStepResult(step, err)
+		}
+		files = append(files, f)
+		executor.Stderr = f
+	}
+
+	// run, unless canceled after the previous step
+	if !p.setSandbox(sandbox) {
+		return p.newFailedStepResult(step, errPipelineCanceled)
+	}
+	executor.Run()
+	p.setSandbox(nil)
+
+	// check
+	var sr = PipelineStepResult{Name: step.Name, OK: true, Result: executor.Result}
+	if r := executor.Result; r.Status != StatusOK {
+		sr.OK, sr.Reason = false, fmt.Sprintf("%s: %s", r.Status, r.Reason)
+	} else if r.ExitCode != 0 {
+		sr.OK, sr.Reason = false, fmt.Sprintf("exit with code %d", r.ExitCode)
+	} else {
+		for _, output := range step.Outputs {
+			if _, err := os.Stat(filepath.Join(workspace, output)); err != nil {
+				sr.OK, sr.Reason = false, fmt.Sprintf("output(%s): %s", output, err.Error())
+				break
+			}
+		}
+	}
+	return sr
+}
+
+func (p *Pipeline) newFailedStepResult(step PipelineStep, err error) PipelineStepResult {
+	var r = Result{Status: StatusSandboxFailure, Reason: err.Error()}
+	return PipelineStepResult{Name: step.Name, OK: false, Reason: err.Error(), Result: r}
+}
+
+// resolve returns the path relative to the spec file
+func (p *Pipeline) resolve(path string) string {
+	if filepath.IsAbs(path) || p.dir == "" {
+		return path
+	}
+	return filepath.Join(p.dir, path)
+}
+
+// copyFile copies the regular file, including its permission bits, e.g. the executable one
+func copyFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,25 @@
+package gsandbox
+
+import (
+	"testing"
+)
+
+func TestPipelineCanceled(t *testing.T) {
+	var p = NewPipeline()
+	if err := p.LoadFromData([]byte(`
+steps:
+  - {name: compile, policy: gcc, prog: /usr/bin/gcc, args: [main.c]}
+  - {name: run, policy: default, prog: ./a.out}
+`)); err != nil {
+		t.Fatal(err)
+	}
+
+	p.Cleanup() // e.g. a Ctrl-C between the steps
+	var report = p.Run()
+	if report.OK || report.FailedStep != "compile" || len(report.Steps) != 1 {
+		t.Fatalf("ok: %v, failedStep: %s, steps: %d", report.OK, report.FailedStep, len(report.Steps))
+	}
+	if reason := report.Steps[0].Reason; reason != errPipelineCanceled.Error() {
+		t.Fatalf("reason: %s", reason)
+	}
+}
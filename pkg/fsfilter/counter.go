@@ -1,10 +1,14 @@
 package fsfilter
 
+import (
+	"sync/atomic"
+)
+
+// Counter is safe for concurrent use, e.g. by the executors of a server
 type Counter struct {
-	v int
+	v int64
 }
 
 func (c *Counter) Inc() int {
-	c.v++
-	return c.v
+	return int(atomic.AddInt64(&c.v, 1))
 }
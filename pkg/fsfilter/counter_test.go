@@ -0,0 +1,28 @@
+package fsfilter
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCounterConcurrent(t *testing.T) {
+	var c = &Counter{}
+	var seen sync.Map
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() { // e.g. the tracers of the executors of a server
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if _, loaded := seen.LoadOrStore(c.Inc(), struct{}{}); loaded {
+					t.Error("duplicate value")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if v := c.Inc(); v != 8001 {
+		t.Fatalf("value: %d, want 8001", v)
+	}
+}
@@ -0,0 +1,40 @@
+package fsfilter
+
+import (
+	"os"
+)
+
+// FdTable is the file descriptor table of a process, may be shared by processes
+// created with CLONE_FILES, e.g. threads.
+type FdTable struct {
+	trackedFds map[int]File
+	cloexecFds map[int]struct{}
+	memFiles   []File // allowed memfs files, e.g. pipe, socket
+}
+
+func NewFdTable() *FdTable {
+	t := &FdTable{trackedFds: make(map[int]File), cloexecFds: make(map[int]struct{})}
+	return t
+}
+
+func (t *FdTable) Clone() *FdTable {
+	trackedFds := make(map[int]File)
+	for k, v := range t.trackedFds {
+		trackedFds[k] = v
+	}
+
+	cloexecFds := make(map[int]struct{})
+	for k, v := range t.cloexecFds {
+		cloexecFds[k] = v
+	}
+
+	memFiles := make([]File, len(t.memFiles))
+	copy(memFiles, t.memFiles)
+
+	return &FdTable{trackedFds: trackedFds, cloexecFds: cloexecFds, memFiles: memFiles}
+}
+
+func (t *FdTable) addMemFile(fullpath string, perm int) {
+	var file = NewFile(fullpath, os.FileMode(perm))
+	t.memFiles = append(t.memFiles, *file)
+}
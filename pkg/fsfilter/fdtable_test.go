@@ -0,0 +1,62 @@
+package fsfilter
+
+import (
+	"os"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestFdTableShareWithParent(t *testing.T) {
+	var parent = NewFsFilter(os.Getpid())
+	var thread = NewFsFilterShareWithParent(os.Getpid(), parent) // clone(CLONE_FILES), e.g. pthread_create
+
+	if _, err := thread.TrackFd(3, "/etc/hostname", unix.AT_FDCWD); err != nil {
+		t.Fatal(err)
+	}
+	if f, err := parent.GetTrackdFile(3); err != nil || f.GetFullpath() != "/etc/hostname" {
+		t.Fatalf("fd opened by the thread is not tracked by the parent: %v", err)
+	}
+
+	parent.UntrackFd(3)
+	if _, err := thread.GetTrackdFile(3); err == nil {
+		t.Fatal("fd closed by the parent is still tracked by the thread")
+	}
+}
+
+func TestFdTableInheritFromParent(t *testing.T) {
+	var parent = NewFsFilter(os.Getpid())
+	if _, err := parent.TrackFd(3, "/etc/hostname", unix.AT_FDCWD); err != nil {
+		t.Fatal(err)
+	}
+	var child = NewFsFilterInheritFromParent(os.Getpid(), parent) // fork, vfork
+
+	if f, err := child.GetTrackdFile(3); err != nil || f.GetFullpath() != "/etc/hostname" {
+		t.Fatalf("fd of the parent is not inherited by the child: %v", err)
+	}
+
+	child.UntrackFd(3)
+	if _, err := child.TrackFd(4, "/etc/hosts", unix.AT_FDCWD); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parent.GetTrackdFile(3); err != nil {
+		t.Fatal("fd closed by the child is untracked by the parent")
+	}
+	if _, err := parent.GetTrackdFile(4); err == nil {
+		t.Fatal("fd opened by the child is tracked by the parent")
+	}
+}
+
+func TestFdTableUnshare(t *testing.T) {
+	var parent = NewFsFilter(os.Getpid())
+	var thread = NewFsFilterShareWithParent(os.Getpid(), parent)
+	if _, err := parent.TrackFd(3, "/etc/hostname", unix.AT_FDCWD); err != nil {
+		t.Fatal(err)
+	}
+
+	thread.UnshareFdTable() // unshare(CLONE_FILES)
+	thread.UntrackFd(3)
+	if _, err := parent.GetTrackdFile(3); err != nil {
+		t.Fatal("fd closed after unshare is untracked by the parent")
+	}
+}
@@ -52,3 +52,13 @@ func (f *File) hasEntry(fullpath string) bool {
 func (f *File) hasPerm(perm int) bool {
 	return int(f.mode.Perm())&perm != 0
 }
+
+// IsStdout reports whether the file is the stdout inherited from the parent, e.g. dup2(1, 3)
+func (f *File) IsStdout() bool {
+	return f.fullpath == _FILE_FULLPATH_STDOUT
+}
+
+// IsStderr reports whether the file is the stderr inherited from the parent
+func (f *File) IsStderr() bool {
+	return f.fullpath == _FILE_FULLPATH_STDERR
+}
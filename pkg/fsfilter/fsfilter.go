@@ -20,11 +20,12 @@ var (
 type FsFilter struct {
 	pid          int
 	allowedFiles []File
-	trackedFds   map[int]File
+	ttys         []string // pseudo-terminals, treated as the stdout memfs entry
+	fdTable      *FdTable
 }
 
 func NewFsFilter(pid int) *FsFilter {
-	fs := &FsFilter{pid: pid, trackedFds: make(map[int]File)}
+	fs := &FsFilter{pid: pid, fdTable: NewFdTable()}
 
 	// builtin allowed files - rd-lists
 	_ = fs.AddAllowedFile(_FILE_FULLPATH_STDIN_, FILE_RD)
@@ -43,16 +44,23 @@ func NewFsFilter(pid int) *FsFilter {
 	return fs
 }
 
+// NewFsFilterInheritFromParent creates a filter with a copy of the parent's fd table, e.g. fork
 func NewFsFilterInheritFromParent(pid int, parentFsFilter *FsFilter) *FsFilter {
 	allowedFiles := make([]File, len(parentFsFilter.allowedFiles))
 	copy(allowedFiles, parentFsFilter.allowedFiles)
 
-	trackedFds := make(map[int]File)
-	for k, v := range parentFsFilter.trackedFds {
-		trackedFds[k] = v
-	}
+	fs := &FsFilter{pid: pid, allowedFiles: allowedFiles, ttys: parentFsFilter.ttys, fdTable: parentFsFilter.fdTable.Clone()}
+	return fs
+}
+
+// NewFsFilterShareWithParent creates a filter sharing the parent's fd table, e.g. clone(CLONE_FILES)
+//
+// There is nothing to do with CLONE_FS, the cwd is always read from /proc/[pid]/cwd.
+func NewFsFilterShareWithParent(pid int, parentFsFilter *FsFilter) *FsFilter {
+	allowedFiles := make([]File, len(parentFsFilter.allowedFiles))
+	copy(allowedFiles, parentFsFilter.allowedFiles)
 
-	fs := &FsFilter{pid: pid, allowedFiles: allowedFiles, trackedFds: trackedFds}
+	fs := &FsFilter{pid: pid, allowedFiles: allowedFiles, ttys: parentFsFilter.ttys, fdTable: parentFsFilter.fdTable}
 	return fs
 }
 
@@ -105,6 +113,12 @@ func (fs *FsFilter) AddAllowedFile(path string, perm int) error {
 	return nil
 }
 
+// AddTTY treats the pseudo-terminal, which is the stdin/stdout/stderr of the process, as the stdout memfs entry,
+// so it is readable and writable, and the writes are counted as stdout once it is opened, e.g. /dev/tty
+func (fs *FsFilter) AddTTY(path string) {
+	fs.ttys = append(fs.ttys, filepath.Clean(path))
+}
+
 func (fs *FsFilter) AllowRead(path string, dirfd int) (bool, error) {
 	return fs.allow(path, dirfd, FILE_RD)
 }
@@ -118,7 +132,7 @@ func (fs *FsFilter) AllowExecute(path string, dirfd int) (bool, error) {
 }
 
 func (fs *FsFilter) GetTrackdFile(fd int) (File, error) {
-	f, ok := fs.trackedFds[fd]
+	f, ok := fs.fdTable.trackedFds[fd]
 	if !ok {
 		return File{}, fmt.Errorf("fd(%d) not found", fd)
 	}
@@ -126,65 +140,129 @@ func (fs *FsFilter) GetTrackdFile(fd int) (File, error) {
 }
 
 func (fs *FsFilter) TrackFd(fd int, path string, dirfd int) (File, error) {
-	fullpath, err := fs.getAbs(path, dirfd)
+	fullpath, err := fs.GetFullpath(path, dirfd)
 	if err != nil {
 		return File{}, err
 	}
 
-	var f = File{fullpath: fullpath}
-	fs.trackedFds[fd] = f
+	var f = File{fullpath: fs.resolveTTY(fullpath)}
+	fs.fdTable.trackedFds[fd] = f
+	delete(fs.fdTable.cloexecFds, fd)
 	return f, nil
 }
 
+// TrackProcFd tracks a fd which was installed in the process by others, e.g. SCM_RIGHTS, pidfd_getfd.
+// Regular files are tracked with its real path, anything else (socket, pipe, anon_inode) as a memfs file.
+func (fs *FsFilter) TrackProcFd(fd int) (File, error) {
+	var link = fmt.Sprintf("/proc/%d/fd/%d", fs.pid, fd)
+	var buf = make([]byte, unix.PathMax)
+
+	n, err := syscall.Readlink(link, buf)
+	if err != nil {
+		return File{}, err
+	}
+	if fullpath := string(buf[:n]); filepath.IsAbs(fullpath) {
+		return fs.TrackFd(fd, fullpath, unix.AT_FDCWD)
+	} else {
+		return fs.TrackMemFd(fd, FILE_WR)
+	}
+}
+
 func (fs *FsFilter) TrackMemFd(fd int, perm int) (File, error) {
 	var fullpath = fs.getMemFilePath()
 	switch perm {
 	case FILE_RD:
-		if err := fs.AddAllowedFile(fullpath, FILE_RD); err != nil {
-			return File{}, err
-		}
+		fs.fdTable.addMemFile(fullpath, FILE_RD)
 	case FILE_WR:
-		if err := fs.AddAllowedFile(fullpath, FILE_RD); err != nil {
-			return File{}, err
-		}
-		if err := fs.AddAllowedFile(fullpath, FILE_WR); err != nil {
-			return File{}, err
-		}
+		fs.fdTable.addMemFile(fullpath, FILE_RD|FILE_WR)
 	}
 
-	var f = File{fullpath: fullpath}
-	fs.trackedFds[fd] = f
+	var f = File{fullpath: fs.resolveTTY(fullpath)}
+	fs.fdTable.trackedFds[fd] = f
+	delete(fs.fdTable.cloexecFds, fd)
 	return f, nil
 }
 
 func (fs *FsFilter) UntrackFd(fd int) {
-	delete(fs.trackedFds, fd)
+	delete(fs.fdTable.trackedFds, fd)
+	delete(fs.fdTable.cloexecFds, fd)
+}
+
+// UnshareFdTable gives the process its own copy of the fd table, e.g. unshare(CLONE_FILES)
+func (fs *FsFilter) UnshareFdTable() {
+	fs.fdTable = fs.fdTable.Clone()
+}
+
+// UntrackFdRange untracks all fds in [first, last], e.g. close_range
+func (fs *FsFilter) UntrackFdRange(first int, last int) {
+	for fd := range fs.fdTable.trackedFds {
+		if fd >= first && fd <= last {
+			fs.UntrackFd(fd)
+		}
+	}
+}
+
+// SetCloexec sets/clears the close-on-exec flag of fd, e.g. O_CLOEXEC, FD_CLOEXEC
+func (fs *FsFilter) SetCloexec(fd int, cloexec bool) {
+	if _, ok := fs.fdTable.trackedFds[fd]; !ok {
+		return
+	}
+	if cloexec {
+		fs.fdTable.cloexecFds[fd] = struct{}{}
+	} else {
+		delete(fs.fdTable.cloexecFds, fd)
+	}
+}
+
+// SetCloexecRange sets the close-on-exec flag of all fds in [first, last], e.g. close_range(CLOSE_RANGE_CLOEXEC)
+func (fs *FsFilter) SetCloexecRange(first int, last int) {
+	for fd := range fs.fdTable.trackedFds {
+		if fd >= first && fd <= last {
+			fs.fdTable.cloexecFds[fd] = struct{}{}
+		}
+	}
+}
+
+// UntrackCloexecFds untracks all fds which are closed by a successful execve, returns the fds
+func (fs *FsFilter) UntrackCloexecFds() []int {
+	var fds = make([]int, 0, len(fs.fdTable.cloexecFds))
+	for fd := range fs.fdTable.cloexecFds {
+		fds = append(fds, fd)
+	}
+	for _, fd := range fds {
+		fs.UntrackFd(fd)
+	}
+	return fds
 }
 
 func (fs *FsFilter) allow(path string, dirfd int, perm int) (bool, error) {
-	fullpath, err := fs.getAbs(path, dirfd)
+	fullpath, err := fs.GetFullpath(path, dirfd)
 	if err != nil {
 		return false, err
 	}
-
-	for _, f := range fs.allowedFiles {
-		var ok = false
-		switch perm {
-		case FILE_RD:
-			ok = f.AllowRead(fullpath)
-		case FILE_WR:
-			ok = f.AllowWrite(fullpath)
-		case FILE_EX:
-			ok = f.AllowExecute(fullpath)
-		}
-		if ok {
-			return true, nil
+	fullpath = fs.resolveTTY(fullpath)
+
+	for _, files := range [][]File{fs.allowedFiles, fs.fdTable.memFiles} {
+		for _, f := range files {
+			var ok = false
+			switch perm {
+			case FILE_RD:
+				ok = f.AllowRead(fullpath)
+			case FILE_WR:
+				ok = f.AllowWrite(fullpath)
+			case FILE_EX:
+				ok = f.AllowExecute(fullpath)
+			}
+			if ok {
+				return true, nil
+			}
 		}
 	}
 	return false, nil
 }
 
-func (fs *FsFilter) getAbs(path string, dirfd int) (string, error) {
+// GetFullpath resolves the path relative to dirfd, plz see openat(2)
+func (fs *FsFilter) GetFullpath(path string, dirfd int) (string, error) {
 	if filepath.IsAbs(path) {
 		return path, nil
 	}
@@ -198,7 +276,7 @@ func (fs *FsFilter) getAbs(path string, dirfd int) (string, error) {
 		}
 	}
 
-	f, ok := fs.trackedFds[dirfd]
+	f, ok := fs.fdTable.trackedFds[dirfd]
 	if ok {
 		return filepath.Join(f.fullpath, path), nil
 	} else {
@@ -206,6 +284,15 @@ func (fs *FsFilter) getAbs(path string, dirfd int) (string, error) {
 	}
 }
 
+func (fs *FsFilter) resolveTTY(fullpath string) string {
+	for _, tty := range fs.ttys {
+		if filepath.Clean(fullpath) == tty {
+			return _FILE_FULLPATH_STDOUT
+		}
+	}
+	return fullpath
+}
+
 func (fs *FsFilter) getCwd() (string, error) {
 	var cwd = fmt.Sprintf("/proc/%d/cwd", fs.pid)
 	var buf = make([]byte, unix.PathMax)
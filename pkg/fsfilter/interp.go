@@ -0,0 +1,75 @@
+package fsfilter
+
+import (
+	"bytes"
+	"debug/elf"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// BINPRM_BUF_SIZE, the kernel only reads the first 256 bytes of a script
+	maxShebangLen = 256
+)
+
+// GetInterpreter returns the interpreter which the kernel will load to run the file, e.g.
+//
+//   - a script: the interpreter in the shebang(#!) line
+//   - a dynamically linked ELF: the program interpreter(PT_INTERP), e.g. /lib64/ld-linux-x86-64.so.2
+//
+// An empty string is returned if the file needs no interpreter.
+func (fs *FsFilter) GetInterpreter(path string, dirfd int) (string, error) {
+	fullpath, err := fs.GetFullpath(path, dirfd)
+	if err != nil {
+		return "", err
+	}
+
+	// the file is opened through the tracee's root directory, in case of chroot(2)
+	f, err := os.Open(fmt.Sprintf("/proc/%d/root%s", fs.pid, fullpath))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var buf = make([]byte, maxShebangLen)
+	n, _ := f.ReadAt(buf, 0)
+	buf = buf[:n]
+
+	// script
+	if bytes.HasPrefix(buf, []byte("#!")) {
+		var line = string(buf[2:])
+		if i := strings.IndexByte(line, '\n'); i >= 0 {
+			line = line[:i]
+		}
+		var fields = strings.Fields(line)
+		if len(fields) == 0 {
+			return "", fmt.Errorf("invalid shebang(%s)", fullpath)
+		}
+		return fs.GetFullpath(fields[0], unix.AT_FDCWD)
+	}
+
+	// ELF
+	if bytes.HasPrefix(buf, []byte(elf.ELFMAG)) {
+		ef, err := elf.NewFile(f)
+		if err != nil {
+			return "", err
+		}
+		defer ef.Close()
+
+		for _, prog := range ef.Progs {
+			if prog.Type != elf.PT_INTERP {
+				continue
+			}
+			var interp = make([]byte, prog.Filesz)
+			if _, err := prog.ReadAt(interp, 0); err != nil {
+				return "", err
+			}
+			return string(bytes.TrimRight(interp, "\x00")), nil
+		}
+	}
+
+	return "", nil
+}
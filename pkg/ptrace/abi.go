@@ -0,0 +1,133 @@
+package ptrace
+
+import (
+	"fmt"
+
+	"github.com/seccomp/libseccomp-golang"
+)
+
+// Syscall ABI - all available names
+const (
+	ArchX86_64  = "x86_64"
+	ArchI386    = "i386"
+	ArchX32     = "x32"
+	ArchAArch64 = "aarch64"
+	ArchARM     = "arm"
+)
+
+// Syscall ABI - AUDIT_ARCH_*, see linux/audit.h
+const (
+	auditArchX86_64  = 0xc000003e
+	auditArchI386    = 0x40000003
+	auditArchAArch64 = 0xc00000b7
+	auditArchARM     = 0x40000028
+)
+
+// Syscall ABI - __X32_SYSCALL_BIT, see arch/x86/include/uapi/asm/unistd.h
+const x32SyscallBit = 0x40000000
+
+// Syscall ABI - the canonical number of a syscall which is not available on x86-64, e.g. socketcall
+const sysnumUnknown = ^uint(0)
+
+// Syscall ABI
+type syscallABI struct {
+	name      string            // e.g. x86_64, i386
+	scmpArch  seccomp.ScmpArch  // used to lookup syscall name
+	wordSize  int               // size of a pointer in tracee memory
+	compat    bool              // a compat ABI, e.g. i386 on x86-64
+	canonical bool              // syscall numbers are canonical, i.e. x86-64
+	aliases   map[string]string // syscall name => canonical syscall name, e.g. stat64 => stat
+
+	oLargefile int // O_LARGEFILE, cleared when decoding open flags of a compat syscall
+}
+
+// Syscall ABI - lookup name and canonical number of a syscall
+func (abi *syscallABI) lookup(nr uint) (string, uint, error) {
+	var name, err = seccomp.ScmpSyscall(nr).GetNameByArch(abi.scmpArch)
+	if err != nil {
+		return "", 0, fmt.Errorf("ScmpGetName: %s", err.Error())
+	}
+	if abi.canonical {
+		return name, nr, nil
+	}
+
+	var canonicalName = name
+	if alias, ok := abi.aliases[name]; ok {
+		canonicalName = alias
+	}
+	if canonicalNR, ok := sysnumTable[canonicalName]; ok {
+		return name, canonicalNR, nil
+	} else {
+		return name, sysnumUnknown, nil
+	}
+}
+
+// IsCompatArch reports whether the name is a compat ABI, e.g. i386 on x86-64
+func IsCompatArch(name string) bool {
+	switch name {
+	case ArchI386, ArchX32, ArchARM:
+		return true
+	default:
+		return false
+	}
+}
+
+// Syscall ABI - 32-bit syscall names which differ from the x86-64 ones
+var compat32Aliases = map[string]string{
+	"_llseek":                      "lseek",
+	"_newselect":                   "select",
+	"chown32":                      "chown",
+	"clock_adjtime64":              "clock_adjtime",
+	"clock_getres_time64":          "clock_getres",
+	"clock_gettime64":              "clock_gettime",
+	"clock_nanosleep_time64":       "clock_nanosleep",
+	"clock_settime64":              "clock_settime",
+	"fadvise64_64":                 "fadvise64",
+	"fchown32":                     "fchown",
+	"fcntl64":                      "fcntl",
+	"fstat64":                      "fstat",
+	"fstatat64":                    "newfstatat",
+	"fstatfs64":                    "fstatfs",
+	"ftruncate64":                  "ftruncate",
+	"futex_time64":                 "futex",
+	"getegid32":                    "getegid",
+	"geteuid32":                    "geteuid",
+	"getgid32":                     "getgid",
+	"getgroups32":                  "getgroups",
+	"getresgid32":                  "getresgid",
+	"getresuid32":                  "getresuid",
+	"getuid32":                     "getuid",
+	"io_pgetevents_time64":         "io_pgetevents",
+	"lchown32":                     "lchown",
+	"lstat64":                      "lstat",
+	"mmap2":                        "mmap",
+	"mq_timedreceive_time64":       "mq_timedreceive",
+	"mq_timedsend_time64":          "mq_timedsend",
+	"ppoll_time64":                 "ppoll",
+	"pselect6_time64":              "pselect6",
+	"recvmmsg_time64":              "recvmmsg",
+	"rt_sigtimedwait_time64":       "rt_sigtimedwait",
+	"sched_rr_get_interval_time64": "sched_rr_get_interval",
+	"semtimedop_time64":            "semtimedop",
+	"sendfile64":                   "sendfile",
+	"setfsgid32":                   "setfsgid",
+	"setfsuid32":                   "setfsuid",
+	"setgid32":                     "setgid",
+	"setgroups32":                  "setgroups",
+	"setregid32":                   "setregid",
+	"setresgid32":                  "setresgid",
+	"setresuid32":                  "setresuid",
+	"setreuid32":                   "setreuid",
+	"setuid32":                     "setuid",
+	"stat64":                       "stat",
+	"statfs64":                     "statfs",
+	"timer_gettime64":              "timer_gettime",
+	"timer_settime64":              "timer_settime",
+	"timerfd_gettime64":            "timerfd_gettime",
+	"timerfd_settime64":            "timerfd_settime",
+	"truncate64":                   "truncate",
+	"ugetrlimit":                   "getrlimit",
+	"umount":                       "umount2",
+	"utimensat_time64":             "utimensat",
+	"waitpid":                      "wait4",
+}
@@ -0,0 +1,172 @@
+package ptrace
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// constantTable holds the named constants known by stringers, e.g. O_RDONLY, TCGETS
+var constantTable = map[string]uint64{
+	"PROT_NONE":  unix.PROT_NONE,
+	"F_OK":       unix.F_OK,
+	"AT_EACCESS": unix.AT_EACCESS,
+
+	"O_APPEND":              uint64(O_APPEND),
+	"O_ASYNC":               uint64(O_ASYNC),
+	"O_CLOEXEC":             uint64(O_CLOEXEC),
+	"O_CREAT":               uint64(O_CREAT),
+	"O_DIRECT":              uint64(O_DIRECT),
+	"O_DSYNC":               uint64(O_DSYNC),
+	"O_EXCL":                uint64(O_EXCL),
+	"O_NOATIME":             uint64(O_NOATIME),
+	"O_NOCTTY":              uint64(O_NOCTTY),
+	"O_NONBLOCK":            uint64(O_NONBLOCK),
+	"O_PATH":                uint64(O_PATH),
+	"O_SYNC":                uint64(O_SYNC),
+	"O_TMPFILE":             uint64(O_TMPFILE),
+	"O_TRUNC":               uint64(O_TRUNC),
+	"O_RDONLY":              uint64(O_RDONLY),
+	"O_WRONLY":              uint64(O_WRONLY),
+	"O_RDWR":                uint64(O_RDWR),
+	"F_DUPFD":               uint64(F_DUPFD),
+	"F_GETFD":               uint64(F_GETFD),
+	"F_SETFD":               uint64(F_SETFD),
+	"F_GETFL":               uint64(F_GETFL),
+	"F_SETFL":               uint64(F_SETFL),
+	"F_GETLK":               uint64(F_GETLK),
+	"F_SETLK":               uint64(F_SETLK),
+	"F_SETLKW":              uint64(F_SETLKW),
+	"F_SETOWN":              uint64(F_SETOWN),
+	"F_GETOWN":              uint64(F_GETOWN),
+	"F_SETSIG":              uint64(F_SETSIG),
+	"F_GETSIG":              uint64(F_GETSIG),
+	"F_SETOWN_EX":           uint64(F_SETOWN_EX),
+	"F_GETOWN_EX":           uint64(F_GETOWN_EX),
+	"F_DUPFD_CLOEXEC":       uint64(F_DUPFD_CLOEXEC),
+	"F_SETPIPE_SZ":          uint64(F_SETPIPE_SZ),
+	"F_GETPIPE_SZ":          uint64(F_GETPIPE_SZ),
+	"CLONE_NEWTIME":         uint64(CLONE_NEWTIME),
+	"CLONE_VM":              uint64(CLONE_VM),
+	"CLONE_FS":              uint64(CLONE_FS),
+	"CLONE_FILES":           uint64(CLONE_FILES),
+	"CLONE_SIGHAND":         uint64(CLONE_SIGHAND),
+	"CLONE_PIDFD":           uint64(CLONE_PIDFD),
+	"CLONE_PTRACE":          uint64(CLONE_PTRACE),
+	"CLONE_VFORK":           uint64(CLONE_VFORK),
+	"CLONE_PARENT":          uint64(CLONE_PARENT),
+	"CLONE_THREAD":          uint64(CLONE_THREAD),
+	"CLONE_NEWNS":           uint64(CLONE_NEWNS),
+	"CLONE_SYSVSEM":         uint64(CLONE_SYSVSEM),
+	"CLONE_SETTLS":          uint64(CLONE_SETTLS),
+	"CLONE_PARENT_SETTID":   uint64(CLONE_PARENT_SETTID),
+	"CLONE_CHILD_CLEARTID":  uint64(CLONE_CHILD_CLEARTID),
+	"CLONE_DETACHED":        uint64(CLONE_DETACHED),
+	"CLONE_UNTRACED":        uint64(CLONE_UNTRACED),
+	"CLONE_CHILD_SETTID":    uint64(CLONE_CHILD_SETTID),
+	"CLONE_NEWCGROUP":       uint64(CLONE_NEWCGROUP),
+	"CLONE_NEWUTS":          uint64(CLONE_NEWUTS),
+	"CLONE_NEWIPC":          uint64(CLONE_NEWIPC),
+	"CLONE_NEWUSER":         uint64(CLONE_NEWUSER),
+	"CLONE_NEWPID":          uint64(CLONE_NEWPID),
+	"CLONE_NEWNET":          uint64(CLONE_NEWNET),
+	"CLONE_IO":              uint64(CLONE_IO),
+	"CLONE_CLEAR_SIGHAND":   uint64(CLONE_CLEAR_SIGHAND),
+	"CLONE_INTO_CGROUP":     uint64(CLONE_INTO_CGROUP),
+	"PROT_READ":             uint64(PROT_READ),
+	"PROT_WRITE":            uint64(PROT_WRITE),
+	"PROT_EXEC":             uint64(PROT_EXEC),
+	"PROT_GROWSDOWN":        uint64(PROT_GROWSDOWN),
+	"PROT_GROWSUP":          uint64(PROT_GROWSUP),
+	"MAP_SHARED":            uint64(MAP_SHARED),
+	"MAP_PRIVATE":           uint64(MAP_PRIVATE),
+	"MAP_SHARED_VALIDATE":   uint64(MAP_SHARED_VALIDATE),
+	"MAP_FIXED":             uint64(MAP_FIXED),
+	"MAP_ANONYMOUS":         uint64(MAP_ANONYMOUS),
+	"MAP_GROWSDOWN":         uint64(MAP_GROWSDOWN),
+	"MAP_DENYWRITE":         uint64(MAP_DENYWRITE),
+	"MAP_EXECUTABLE":        uint64(MAP_EXECUTABLE),
+	"MAP_LOCKED":            uint64(MAP_LOCKED),
+	"MAP_NORESERVE":         uint64(MAP_NORESERVE),
+	"MAP_POPULATE":          uint64(MAP_POPULATE),
+	"MAP_NONBLOCK":          uint64(MAP_NONBLOCK),
+	"MAP_STACK":             uint64(MAP_STACK),
+	"MAP_HUGETLB":           uint64(MAP_HUGETLB),
+	"MAP_SYNC":              uint64(MAP_SYNC),
+	"MAP_FIXED_NOREPLACE":   uint64(MAP_FIXED_NOREPLACE),
+	"TCGETS":                uint64(TCGETS),
+	"TCSETS":                uint64(TCSETS),
+	"TCSETSW":               uint64(TCSETSW),
+	"TCSETSF":               uint64(TCSETSF),
+	"TCXONC":                uint64(TCXONC),
+	"TCFLSH":                uint64(TCFLSH),
+	"TIOCSCTTY":             uint64(TIOCSCTTY),
+	"TIOCGPGRP":             uint64(TIOCGPGRP),
+	"TIOCSPGRP":             uint64(TIOCSPGRP),
+	"TIOCOUTQ":              uint64(TIOCOUTQ),
+	"TIOCGWINSZ":            uint64(TIOCGWINSZ),
+	"TIOCSWINSZ":            uint64(TIOCSWINSZ),
+	"FIONREAD":              uint64(FIONREAD),
+	"TIOCNOTTY":             uint64(TIOCNOTTY),
+	"FIONBIO":               uint64(FIONBIO),
+	"FIONCLEX":              uint64(FIONCLEX),
+	"FIOCLEX":               uint64(FIOCLEX),
+	"FIOASYNC":              uint64(FIOASYNC),
+	"TIOCGPTN":              uint64(TIOCGPTN),
+	"TIOCSPTLCK":            uint64(TIOCSPTLCK),
+	"X_OK":                  uint64(X_OK),
+	"W_OK":                  uint64(W_OK),
+	"R_OK":                  uint64(R_OK),
+	"AT_SYMLINK_NOFOLLOW":   uint64(AT_SYMLINK_NOFOLLOW),
+	"AT_REMOVEDIR":          uint64(AT_REMOVEDIR),
+	"AT_SYMLINK_FOLLOW":     uint64(AT_SYMLINK_FOLLOW),
+	"AT_NO_AUTOMOUNT":       uint64(AT_NO_AUTOMOUNT),
+	"AT_EMPTY_PATH":         uint64(AT_EMPTY_PATH),
+	"AT_STATX_FORCE_SYNC":   uint64(AT_STATX_FORCE_SYNC),
+	"AT_STATX_DONT_SYNC":    uint64(AT_STATX_DONT_SYNC),
+	"AT_RECURSIVE":          uint64(AT_RECURSIVE),
+	"SEEK_SET":              uint64(SEEK_SET),
+	"SEEK_CUR":              uint64(SEEK_CUR),
+	"SEEK_END":              uint64(SEEK_END),
+	"SEEK_DATA":             uint64(SEEK_DATA),
+	"SEEK_HOLE":             uint64(SEEK_HOLE),
+	"RLIMIT_CPU":            uint64(RLIMIT_CPU),
+	"RLIMIT_FSIZE":          uint64(RLIMIT_FSIZE),
+	"RLIMIT_DATA":           uint64(RLIMIT_DATA),
+	"RLIMIT_STACK":          uint64(RLIMIT_STACK),
+	"RLIMIT_CORE":           uint64(RLIMIT_CORE),
+	"RLIMIT_RSS":            uint64(RLIMIT_RSS),
+	"RLIMIT_NPROC":          uint64(RLIMIT_NPROC),
+	"RLIMIT_NOFILE":         uint64(RLIMIT_NOFILE),
+	"RLIMIT_MEMLOCK":        uint64(RLIMIT_MEMLOCK),
+	"RLIMIT_AS":             uint64(RLIMIT_AS),
+	"RLIMIT_LOCKS":          uint64(RLIMIT_LOCKS),
+	"RLIMIT_SIGPENDING":     uint64(RLIMIT_SIGPENDING),
+	"RLIMIT_MSGQUEUE":       uint64(RLIMIT_MSGQUEUE),
+	"RLIMIT_NICE":           uint64(RLIMIT_NICE),
+	"RLIMIT_RTPRIO":         uint64(RLIMIT_RTPRIO),
+	"RLIMIT_RTTIME":         uint64(RLIMIT_RTTIME),
+	"FUTEX_WAIT":            uint64(FUTEX_WAIT),
+	"FUTEX_WAKE":            uint64(FUTEX_WAKE),
+	"FUTEX_FD":              uint64(FUTEX_FD),
+	"FUTEX_REQUEUE":         uint64(FUTEX_REQUEUE),
+	"FUTEX_CMP_REQUEUE":     uint64(FUTEX_CMP_REQUEUE),
+	"FUTEX_WAKE_OP":         uint64(FUTEX_WAKE_OP),
+	"FUTEX_LOCK_PI":         uint64(FUTEX_LOCK_PI),
+	"FUTEX_UNLOCK_PI":       uint64(FUTEX_UNLOCK_PI),
+	"FUTEX_TRYLOCK_PI":      uint64(FUTEX_TRYLOCK_PI),
+	"FUTEX_WAIT_BITSET":     uint64(FUTEX_WAIT_BITSET),
+	"FUTEX_WAKE_BITSET":     uint64(FUTEX_WAKE_BITSET),
+	"FUTEX_WAIT_REQUEUE_PI": uint64(FUTEX_WAIT_REQUEUE_PI),
+	"FUTEX_CMP_REQUEUE_PI":  uint64(FUTEX_CMP_REQUEUE_PI),
+	"FUTEX_LOCK_PI2":        uint64(FUTEX_LOCK_PI2),
+}
+
+// LookupConstant returns the value of constant named `name`, e.g. TCGETS, SIGKILL
+func LookupConstant(name string) (uint64, bool) {
+	if v, ok := constantTable[name]; ok {
+		return v, true
+	}
+	if sig := unix.SignalNum(name); sig != 0 {
+		return uint64(sig), true
+	}
+	return 0, false
+}
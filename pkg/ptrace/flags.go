@@ -2,6 +2,8 @@ package ptrace
 
 import (
 	"fmt"
+	"strings"
+	"syscall"
 
 	"golang.org/x/sys/unix"
 )
@@ -9,6 +11,18 @@ import (
 type Fd int
 type FlagOpen int
 type FlagFcntlCmd int
+type FlagClone uint64
+type FlagMmapProt int
+type FlagMmap int
+type FlagIoctlReq int
+type FlagAccessMode int
+type FlagAt int
+type FlagSeekWhence int
+type FlagRlimitResource int
+type FlagFutexOp int
+type Signal int
+type Mode int
+type FileMode int
 
 func (fd Fd) String() string {
 	switch int(fd) {
@@ -65,3 +79,183 @@ func (f FlagOpen) String() string {
 func (f FlagFcntlCmd) String() string {
 	return FlagFcntlCmdStringer(int(f)).String()
 }
+
+func (f FlagClone) String() string {
+	var currFlag = uint64(f)
+	var str = ""
+	for bit := uint64(unix.CLONE_VM); bit <= unix.CLONE_INTO_CGROUP; bit <<= 1 {
+		if currFlag&bit != 0 {
+			str += FlagCloneStringer(bit).String() + "|"
+		}
+		currFlag = currFlag &^ bit
+	}
+
+	// the low byte is CLONE_NEWTIME in clone3(2)/unshare(2), or exit signal in clone(2)
+	switch sig := currFlag & 0xff; {
+	case sig == unix.CLONE_NEWTIME:
+		str += FlagCloneStringer(sig).String()
+	case sig != 0:
+		str += unix.SignalName(syscall.Signal(sig))
+	default:
+		str = strings.TrimSuffix(str, "|")
+	}
+
+	if str == "" {
+		return "0"
+	}
+	return str
+}
+
+func (f FlagMmapProt) String() string {
+	if f == unix.PROT_NONE {
+		return "PROT_NONE"
+	}
+	return stringifyBitmask(int(f), []int{unix.PROT_READ, unix.PROT_WRITE, unix.PROT_EXEC, unix.PROT_GROWSDOWN, unix.PROT_GROWSUP}, func(bit int) string {
+		return FlagMmapProtStringer(bit).String()
+	})
+}
+
+func (f FlagMmap) String() string {
+	// the low bits are the mapping type, others are bit flags
+	var str string
+	switch t := FlagMmapStringer(int(f) & 0xf); t {
+	case MAP_SHARED, MAP_PRIVATE, MAP_SHARED_VALIDATE:
+		str = t.String()
+	default:
+		str = fmt.Sprintf("%#x", int(t))
+	}
+	var rest = int(f) &^ 0xf
+	if rest == 0 {
+		return str
+	}
+	return str + "|" + stringifyBitmask(rest, []int{
+		unix.MAP_FIXED, unix.MAP_ANONYMOUS, unix.MAP_GROWSDOWN, unix.MAP_DENYWRITE, unix.MAP_EXECUTABLE,
+		unix.MAP_LOCKED, unix.MAP_NORESERVE, unix.MAP_POPULATE, unix.MAP_NONBLOCK, unix.MAP_STACK,
+		unix.MAP_HUGETLB, unix.MAP_SYNC, unix.MAP_FIXED_NOREPLACE,
+	}, func(bit int) string {
+		return FlagMmapStringer(bit).String()
+	})
+}
+
+func (f FlagIoctlReq) String() string {
+	var str = FlagIoctlReqStringer(int(f)).String()
+	if strings.HasPrefix(str, "FlagIoctlReqStringer(") {
+		return fmt.Sprintf("%#x", int(f))
+	}
+	return str
+}
+
+func (f FlagAccessMode) String() string {
+	if f == unix.F_OK {
+		return "F_OK"
+	}
+	return stringifyBitmask(int(f), []int{int(R_OK), int(W_OK), int(X_OK)}, func(bit int) string {
+		return FlagAccessModeStringer(bit).String()
+	})
+}
+
+func (f FlagAt) String() string {
+	if f == 0 {
+		return "0"
+	}
+	var bits = make([]int, 0)
+	for bit := unix.AT_SYMLINK_NOFOLLOW; bit <= unix.AT_RECURSIVE; bit <<= 1 {
+		bits = append(bits, bit)
+	}
+	return stringifyBitmask(int(f), bits, func(bit int) string {
+		return FlagAtStringer(bit).String()
+	})
+}
+
+func (f FlagSeekWhence) String() string {
+	if f < FlagSeekWhence(SEEK_SET) || f > FlagSeekWhence(SEEK_HOLE) {
+		return fmt.Sprint(int(f))
+	}
+	return FlagSeekWhenceStringer(int(f)).String()
+}
+
+func (f FlagRlimitResource) String() string {
+	if f < FlagRlimitResource(RLIMIT_CPU) || f > FlagRlimitResource(RLIMIT_RTTIME) {
+		return fmt.Sprint(int(f))
+	}
+	return FlagRlimitResourceStringer(int(f)).String()
+}
+
+func (f FlagFutexOp) String() string {
+	const FUTEX_PRIVATE_FLAG = 128
+	const FUTEX_CLOCK_REALTIME = 256
+
+	var cmd = int(f) &^ (FUTEX_PRIVATE_FLAG | FUTEX_CLOCK_REALTIME)
+	var str string
+	if cmd >= int(FUTEX_WAIT) && cmd <= int(FUTEX_LOCK_PI2) {
+		str = FlagFutexOpStringer(cmd).String()
+	} else {
+		str = fmt.Sprint(cmd)
+	}
+	if f&FUTEX_PRIVATE_FLAG != 0 {
+		str += "_PRIVATE"
+	}
+	if f&FUTEX_CLOCK_REALTIME != 0 {
+		str += "|FUTEX_CLOCK_REALTIME"
+	}
+	return str
+}
+
+func (s Signal) String() string {
+	if name := unix.SignalName(syscall.Signal(s)); name != "" {
+		return name
+	}
+	return fmt.Sprint(int(s))
+}
+
+func (m Mode) String() string {
+	return fmt.Sprintf("%#o", int(m))
+}
+
+func (m FileMode) String() string {
+	var typ string
+	switch m & unix.S_IFMT {
+	case unix.S_IFSOCK:
+		typ = "S_IFSOCK"
+	case unix.S_IFLNK:
+		typ = "S_IFLNK"
+	case unix.S_IFREG:
+		typ = "S_IFREG"
+	case unix.S_IFBLK:
+		typ = "S_IFBLK"
+	case unix.S_IFDIR:
+		typ = "S_IFDIR"
+	case unix.S_IFCHR:
+		typ = "S_IFCHR"
+	case unix.S_IFIFO:
+		typ = "S_IFIFO"
+	default:
+		return Mode(m).String()
+	}
+	return fmt.Sprintf("%s|%#o", typ, int(m&^unix.S_IFMT))
+}
+
+// stringifyBitmask joins the names of the bits set in `v` with "|", unknown bits are appended in hex
+func stringifyBitmask(v int, bits []int, name func(bit int) string) string {
+	var strs = make([]string, 0)
+	for _, bit := range bits {
+		if v&bit != 0 {
+			strs = append(strs, name(bit))
+		}
+		v = v &^ bit
+	}
+	if v != 0 || len(strs) == 0 {
+		strs = append(strs, fmt.Sprintf("%#x", v))
+	}
+	return strings.Join(strs, "|")
+}
+
+// LookupFlagClone returns the value of clone flag named `name`, e.g. CLONE_NEWUSER
+func LookupFlagClone(name string) (FlagClone, bool) {
+	for v, n := range _FlagCloneStringer_map {
+		if n == name {
+			return FlagClone(v), true
+		}
+	}
+	return 0, false
+}
@@ -6,9 +6,18 @@ import (
 
 type FlagOpenStringer int
 type FlagFcntlCmdStringer int
+type FlagCloneStringer int
+type FlagMmapProtStringer int
+type FlagMmapStringer int
+type FlagIoctlReqStringer int
+type FlagAccessModeStringer int
+type FlagAtStringer int
+type FlagSeekWhenceStringer int
+type FlagRlimitResourceStringer int
+type FlagFutexOpStringer int
 
 // https://man7.org/linux/man-pages/man2/open.2.html
-//go:generate stringer -type=FlagOpenStringer -output=flags_stringer_open_string.go
+//go:generate stringer -type=FlagOpenStringer -output=flags_stringer_open_string_$GOARCH.go
 const (
 	O_APPEND   FlagOpenStringer = unix.O_APPEND
 	O_ASYNC    FlagOpenStringer = unix.O_ASYNC
@@ -50,3 +59,162 @@ const (
 	F_SETPIPE_SZ    FlagFcntlCmdStringer = unix.F_SETPIPE_SZ
 	F_GETPIPE_SZ    FlagFcntlCmdStringer = unix.F_GETPIPE_SZ
 )
+
+// https://man7.org/linux/man-pages/man2/clone.2.html
+//go:generate stringer -type=FlagCloneStringer -output=flags_stringer_clone_string.go
+const (
+	CLONE_NEWTIME        FlagCloneStringer = unix.CLONE_NEWTIME
+	CLONE_VM             FlagCloneStringer = unix.CLONE_VM
+	CLONE_FS             FlagCloneStringer = unix.CLONE_FS
+	CLONE_FILES          FlagCloneStringer = unix.CLONE_FILES
+	CLONE_SIGHAND        FlagCloneStringer = unix.CLONE_SIGHAND
+	CLONE_PIDFD          FlagCloneStringer = unix.CLONE_PIDFD
+	CLONE_PTRACE         FlagCloneStringer = unix.CLONE_PTRACE
+	CLONE_VFORK          FlagCloneStringer = unix.CLONE_VFORK
+	CLONE_PARENT         FlagCloneStringer = unix.CLONE_PARENT
+	CLONE_THREAD         FlagCloneStringer = unix.CLONE_THREAD
+	CLONE_NEWNS          FlagCloneStringer = unix.CLONE_NEWNS
+	CLONE_SYSVSEM        FlagCloneStringer = unix.CLONE_SYSVSEM
+	CLONE_SETTLS         FlagCloneStringer = unix.CLONE_SETTLS
+	CLONE_PARENT_SETTID  FlagCloneStringer = unix.CLONE_PARENT_SETTID
+	CLONE_CHILD_CLEARTID FlagCloneStringer = unix.CLONE_CHILD_CLEARTID
+	CLONE_DETACHED       FlagCloneStringer = unix.CLONE_DETACHED
+	CLONE_UNTRACED       FlagCloneStringer = unix.CLONE_UNTRACED
+	CLONE_CHILD_SETTID   FlagCloneStringer = unix.CLONE_CHILD_SETTID
+	CLONE_NEWCGROUP      FlagCloneStringer = unix.CLONE_NEWCGROUP
+	CLONE_NEWUTS         FlagCloneStringer = unix.CLONE_NEWUTS
+	CLONE_NEWIPC         FlagCloneStringer = unix.CLONE_NEWIPC
+	CLONE_NEWUSER        FlagCloneStringer = unix.CLONE_NEWUSER
+	CLONE_NEWPID         FlagCloneStringer = unix.CLONE_NEWPID
+	CLONE_NEWNET         FlagCloneStringer = unix.CLONE_NEWNET
+	CLONE_IO             FlagCloneStringer = unix.CLONE_IO
+	CLONE_CLEAR_SIGHAND  FlagCloneStringer = unix.CLONE_CLEAR_SIGHAND
+	CLONE_INTO_CGROUP    FlagCloneStringer = unix.CLONE_INTO_CGROUP
+)
+
+// https://man7.org/linux/man-pages/man2/mmap.2.html
+//go:generate stringer -type=FlagMmapProtStringer -output=flags_stringer_mmap_prot_string.go
+const (
+	PROT_READ      FlagMmapProtStringer = unix.PROT_READ
+	PROT_WRITE     FlagMmapProtStringer = unix.PROT_WRITE
+	PROT_EXEC      FlagMmapProtStringer = unix.PROT_EXEC
+	PROT_GROWSDOWN FlagMmapProtStringer = unix.PROT_GROWSDOWN
+	PROT_GROWSUP   FlagMmapProtStringer = unix.PROT_GROWSUP
+)
+
+// https://man7.org/linux/man-pages/man2/mmap.2.html
+//go:generate stringer -type=FlagMmapStringer -output=flags_stringer_mmap_string.go
+const (
+	MAP_SHARED          FlagMmapStringer = unix.MAP_SHARED
+	MAP_PRIVATE         FlagMmapStringer = unix.MAP_PRIVATE
+	MAP_SHARED_VALIDATE FlagMmapStringer = unix.MAP_SHARED_VALIDATE
+	MAP_FIXED           FlagMmapStringer = unix.MAP_FIXED
+	MAP_ANONYMOUS       FlagMmapStringer = unix.MAP_ANONYMOUS
+	MAP_GROWSDOWN       FlagMmapStringer = unix.MAP_GROWSDOWN
+	MAP_DENYWRITE       FlagMmapStringer = unix.MAP_DENYWRITE
+	MAP_EXECUTABLE      FlagMmapStringer = unix.MAP_EXECUTABLE
+	MAP_LOCKED          FlagMmapStringer = unix.MAP_LOCKED
+	MAP_NORESERVE       FlagMmapStringer = unix.MAP_NORESERVE
+	MAP_POPULATE        FlagMmapStringer = unix.MAP_POPULATE
+	MAP_NONBLOCK        FlagMmapStringer = unix.MAP_NONBLOCK
+	MAP_STACK           FlagMmapStringer = unix.MAP_STACK
+	MAP_HUGETLB         FlagMmapStringer = unix.MAP_HUGETLB
+	MAP_SYNC            FlagMmapStringer = unix.MAP_SYNC
+	MAP_FIXED_NOREPLACE FlagMmapStringer = unix.MAP_FIXED_NOREPLACE
+)
+
+// https://man7.org/linux/man-pages/man2/ioctl_tty.2.html
+//go:generate stringer -type=FlagIoctlReqStringer -output=flags_stringer_ioctl_req_string.go
+const (
+	TCGETS     FlagIoctlReqStringer = unix.TCGETS
+	TCSETS     FlagIoctlReqStringer = unix.TCSETS
+	TCSETSW    FlagIoctlReqStringer = unix.TCSETSW
+	TCSETSF    FlagIoctlReqStringer = unix.TCSETSF
+	TCXONC     FlagIoctlReqStringer = unix.TCXONC
+	TCFLSH     FlagIoctlReqStringer = unix.TCFLSH
+	TIOCSCTTY  FlagIoctlReqStringer = unix.TIOCSCTTY
+	TIOCGPGRP  FlagIoctlReqStringer = unix.TIOCGPGRP
+	TIOCSPGRP  FlagIoctlReqStringer = unix.TIOCSPGRP
+	TIOCOUTQ   FlagIoctlReqStringer = unix.TIOCOUTQ
+	TIOCGWINSZ FlagIoctlReqStringer = unix.TIOCGWINSZ
+	TIOCSWINSZ FlagIoctlReqStringer = unix.TIOCSWINSZ
+	FIONREAD   FlagIoctlReqStringer = unix.TIOCINQ
+	TIOCNOTTY  FlagIoctlReqStringer = unix.TIOCNOTTY
+	FIONBIO    FlagIoctlReqStringer = 0x5421
+	FIONCLEX   FlagIoctlReqStringer = 0x5450
+	FIOCLEX    FlagIoctlReqStringer = 0x5451
+	FIOASYNC   FlagIoctlReqStringer = 0x5452
+	TIOCGPTN   FlagIoctlReqStringer = unix.TIOCGPTN
+	TIOCSPTLCK FlagIoctlReqStringer = unix.TIOCSPTLCK
+)
+
+// https://man7.org/linux/man-pages/man2/access.2.html
+//go:generate stringer -type=FlagAccessModeStringer -output=flags_stringer_access_mode_string.go
+const (
+	X_OK FlagAccessModeStringer = 0x1
+	W_OK FlagAccessModeStringer = 0x2
+	R_OK FlagAccessModeStringer = 0x4
+)
+
+// https://man7.org/linux/man-pages/man2/openat.2.html, AT_EACCESS shares the value of AT_REMOVEDIR
+//go:generate stringer -type=FlagAtStringer -output=flags_stringer_at_string.go
+const (
+	AT_SYMLINK_NOFOLLOW FlagAtStringer = unix.AT_SYMLINK_NOFOLLOW
+	AT_REMOVEDIR        FlagAtStringer = unix.AT_REMOVEDIR
+	AT_SYMLINK_FOLLOW   FlagAtStringer = unix.AT_SYMLINK_FOLLOW
+	AT_NO_AUTOMOUNT     FlagAtStringer = unix.AT_NO_AUTOMOUNT
+	AT_EMPTY_PATH       FlagAtStringer = unix.AT_EMPTY_PATH
+	AT_STATX_FORCE_SYNC FlagAtStringer = unix.AT_STATX_FORCE_SYNC
+	AT_STATX_DONT_SYNC  FlagAtStringer = unix.AT_STATX_DONT_SYNC
+	AT_RECURSIVE        FlagAtStringer = unix.AT_RECURSIVE
+)
+
+// https://man7.org/linux/man-pages/man2/lseek.2.html
+//go:generate stringer -type=FlagSeekWhenceStringer -output=flags_stringer_seek_whence_string.go
+const (
+	SEEK_SET  FlagSeekWhenceStringer = unix.SEEK_SET
+	SEEK_CUR  FlagSeekWhenceStringer = unix.SEEK_CUR
+	SEEK_END  FlagSeekWhenceStringer = unix.SEEK_END
+	SEEK_DATA FlagSeekWhenceStringer = unix.SEEK_DATA
+	SEEK_HOLE FlagSeekWhenceStringer = unix.SEEK_HOLE
+)
+
+// https://man7.org/linux/man-pages/man2/prlimit.2.html
+//go:generate stringer -type=FlagRlimitResourceStringer -output=flags_stringer_rlimit_resource_string.go
+const (
+	RLIMIT_CPU        FlagRlimitResourceStringer = unix.RLIMIT_CPU
+	RLIMIT_FSIZE      FlagRlimitResourceStringer = unix.RLIMIT_FSIZE
+	RLIMIT_DATA       FlagRlimitResourceStringer = unix.RLIMIT_DATA
+	RLIMIT_STACK      FlagRlimitResourceStringer = unix.RLIMIT_STACK
+	RLIMIT_CORE       FlagRlimitResourceStringer = unix.RLIMIT_CORE
+	RLIMIT_RSS        FlagRlimitResourceStringer = unix.RLIMIT_RSS
+	RLIMIT_NPROC      FlagRlimitResourceStringer = unix.RLIMIT_NPROC
+	RLIMIT_NOFILE     FlagRlimitResourceStringer = unix.RLIMIT_NOFILE
+	RLIMIT_MEMLOCK    FlagRlimitResourceStringer = unix.RLIMIT_MEMLOCK
+	RLIMIT_AS         FlagRlimitResourceStringer = unix.RLIMIT_AS
+	RLIMIT_LOCKS      FlagRlimitResourceStringer = unix.RLIMIT_LOCKS
+	RLIMIT_SIGPENDING FlagRlimitResourceStringer = unix.RLIMIT_SIGPENDING
+	RLIMIT_MSGQUEUE   FlagRlimitResourceStringer = unix.RLIMIT_MSGQUEUE
+	RLIMIT_NICE       FlagRlimitResourceStringer = unix.RLIMIT_NICE
+	RLIMIT_RTPRIO     FlagRlimitResourceStringer = unix.RLIMIT_RTPRIO
+	RLIMIT_RTTIME     FlagRlimitResourceStringer = unix.RLIMIT_RTTIME
+)
+
+// https://man7.org/linux/man-pages/man2/futex.2.html, see linux/futex.h
+//go:generate stringer -type=FlagFutexOpStringer -output=flags_stringer_futex_op_string.go
+const (
+	FUTEX_WAIT            FlagFutexOpStringer = 0
+	FUTEX_WAKE            FlagFutexOpStringer = 1
+	FUTEX_FD              FlagFutexOpStringer = 2
+	FUTEX_REQUEUE         FlagFutexOpStringer = 3
+	FUTEX_CMP_REQUEUE     FlagFutexOpStringer = 4
+	FUTEX_WAKE_OP         FlagFutexOpStringer = 5
+	FUTEX_LOCK_PI         FlagFutexOpStringer = 6
+	FUTEX_UNLOCK_PI       FlagFutexOpStringer = 7
+	FUTEX_TRYLOCK_PI      FlagFutexOpStringer = 8
+	FUTEX_WAIT_BITSET     FlagFutexOpStringer = 9
+	FUTEX_WAKE_BITSET     FlagFutexOpStringer = 10
+	FUTEX_WAIT_REQUEUE_PI FlagFutexOpStringer = 11
+	FUTEX_CMP_REQUEUE_PI  FlagFutexOpStringer = 12
+	FUTEX_LOCK_PI2        FlagFutexOpStringer = 13
+)
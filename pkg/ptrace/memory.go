@@ -0,0 +1,152 @@
+package ptrace
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Tracee memory - the methods to read, tried in order
+const (
+	memReadProcessVMReadv = iota // process_vm_readv(2), one syscall per read
+	memReadProcMem               // pread(2) on /proc/[pid]/mem, e.g. the kernel is built without CONFIG_CROSS_MEMORY_ATTACH
+	memReadPeekData              // PTRACE_PEEKDATA, one syscall per word
+
+	memReadUnprobed = -1 // the method is probed on the first read
+)
+
+// ErrTooLong is returned if a string or an array in the tracee memory exceeds the limit, the value is not
+// truncated, since a truncated value may bypass the policy, e.g. the argument patterns of exec
+var ErrTooLong = errors.New("too long")
+
+var (
+	memReadMethod int32 = memReadUnprobed
+	memPageSize         = uintptr(os.Getpagesize())
+
+	// reusable buffers, shared by tracers running in different goroutines
+	memBufferPool = sync.Pool{
+		New: func() any {
+			return &memBuffer{page: make([]byte, memPageSize), str: make([]byte, 0, unix.PathMax)}
+		},
+	}
+)
+
+type memBuffer struct {
+	page []byte
+	str  []byte
+}
+
+// Tracee memory - the method of the process, e.g. process_vm_readv(2) is denied by the seccomp profile of the
+// container, probed on the process itself once
+func getMemReadMethod() int32 {
+	if method := atomic.LoadInt32(&memReadMethod); method != memReadUnprobed {
+		return method
+	}
+	atomic.CompareAndSwapInt32(&memReadMethod, memReadUnprobed, probeMemReadMethod())
+	return atomic.LoadInt32(&memReadMethod)
+}
+
+func probeMemReadMethod() int32 {
+	var src, dst byte
+	var local = []unix.Iovec{{Base: &dst}}
+	local[0].SetLen(1)
+	var remote = []unix.RemoteIovec{{Base: uintptr(unsafe.Pointer(&src)), Len: 1}}
+	if _, err := unix.ProcessVMReadv(os.Getpid(), local, remote, 0); err == nil {
+		return memReadProcessVMReadv
+	}
+	if f, err := os.Open("/proc/self/mem"); err == nil {
+		_ = f.Close()
+		return memReadProcMem
+	}
+	return memReadPeekData
+}
+
+// Tracee memory - read len(buf) bytes at addr
+//
+// The method is downgraded for all tracees only if it's not implemented by the kernel, a permission error of a
+// tracee downgrades this read only, and the other errors are returned, e.g. the tracee exits.
+func readMemory(pid int, addr uintptr, buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+
+	for method := getMemReadMethod(); ; method++ {
+		switch method {
+		case memReadProcessVMReadv:
+			var local = []unix.Iovec{{Base: &buf[0]}}
+			local[0].SetLen(len(buf))
+			var remote = []unix.RemoteIovec{{Base: addr, Len: len(buf)}}
+			n, err := unix.ProcessVMReadv(pid, local, remote, 0)
+			if err == unix.ENOSYS {
+				atomic.CompareAndSwapInt32(&memReadMethod, memReadProcessVMReadv, memReadProcMem)
+				continue
+			}
+			if err == unix.EPERM || err == unix.EACCES {
+				continue
+			}
+			if err == nil && n != len(buf) { // partial read, the remaining part is not mapped
+				err = unix.EFAULT
+			}
+			if err != nil {
+				return fmt.Errorf("ProcessVMReadv: %s", err.Error())
+			}
+			return nil
+		case memReadProcMem:
+			f, err := os.Open(fmt.Sprintf("/proc/%d/mem", pid))
+			if errors.Is(err, unix.EPERM) || errors.Is(err, unix.EACCES) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("ReadProcMem: %s", err.Error())
+			}
+			_, err = f.ReadAt(buf, int64(addr))
+			_ = f.Close()
+			if err != nil {
+				return fmt.Errorf("ReadProcMem: %s", err.Error())
+			}
+			return nil
+		default:
+			if _, err := unix.PtracePeekData(pid, addr, buf); err != nil {
+				return fmt.Errorf("PeekData: %s", err.Error())
+			}
+			return nil
+		}
+	}
+}
+
+// Tracee memory - read a null-terminated string at addr, at most max bytes
+//
+// The string is read page by page, so a read never crosses into an unmapped page after the terminator.
+func readMemoryString(pid int, addr uintptr, max int) (string, error) {
+	var buf = memBufferPool.Get().(*memBuffer)
+	var str = buf.str[:0]
+	defer func() {
+		buf.str = str[:0]
+		memBufferPool.Put(buf)
+	}()
+
+	for len(str) < max {
+		var n = int(memPageSize - addr%memPageSize)
+		if n > max-len(str) {
+			n = max - len(str)
+		}
+
+		var chunk = buf.page[:n]
+		if err := readMemory(pid, addr, chunk); err != nil {
+			return "", err
+		}
+		if i := bytes.IndexByte(chunk, 0); i >= 0 { // NULL
+			str = append(str, chunk[:i]...)
+			return string(str), nil
+		}
+		str = append(str, chunk...)
+		addr += uintptr(n)
+	}
+	return "", fmt.Errorf("%w: string exceeds %d bytes", ErrTooLong, max)
+}
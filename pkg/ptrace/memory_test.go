@@ -0,0 +1,155 @@
+package ptrace
+
+import (
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// buildTestProg builds testdata/NAME.c with cc, the test is skipped if no cc is available
+func buildTestProg(tb testing.TB, name string) string {
+	tb.Helper()
+
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		tb.Skip("cc not found")
+	}
+
+	var prog = filepath.Join(tb.TempDir(), name)
+	if out, err := exec.Command(cc, "-o", prog, filepath.Join("testdata", name+".c")).CombinedOutput(); err != nil {
+		tb.Fatalf("cc: %s: %s", err.Error(), out)
+	}
+	return prog
+}
+
+// traceTestProg starts prog with PTRACE_TRACEME and traces it until all tracees exit
+func traceTestProg(tb testing.TB, handler TracerHandler, prog string, args ...string) {
+	tb.Helper()
+
+	runtime.LockOSThread() // the tracer must be the thread which started the tracee
+	defer runtime.UnlockOSThread()
+
+	var cmd = exec.Command(prog, args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Ptrace: true}
+	if err := cmd.Start(); err != nil {
+		tb.Fatal(err)
+	}
+	Trace(cmd.Process.Pid, handler)
+	_ = cmd.Process.Release() // already reaped by the tracer
+}
+
+// testTracerHandler records the events, the syscalls are read by onEnter and onLeave, if any
+type testTracerHandler struct {
+	onEnter  func(pid int, curr *Syscall)
+	onLeave  func(pid int, curr *Syscall, prev *Syscall)
+	exited   map[int]syscall.WaitStatus
+	signaled map[int]syscall.WaitStatus
+	err      error
+}
+
+func newTestTracerHandler() *testTracerHandler {
+	return &testTracerHandler{exited: make(map[int]syscall.WaitStatus), signaled: make(map[int]syscall.WaitStatus)}
+}
+
+func (h *testTracerHandler) HandleTracerLogging(pid int, msg string) {}
+
+func (h *testTracerHandler) HandleTracerPanicEvent(err error) {
+	h.err = err
+}
+
+func (h *testTracerHandler) HandleTracerExitedEvent(pid int, ws syscall.WaitStatus, rusage syscall.Rusage) {
+	h.exited[pid] = ws
+}
+
+func (h *testTracerHandler) HandleTracerSignaledEvent(pid int, ws syscall.WaitStatus, rusage syscall.Rusage) {
+	h.signaled[pid] = ws
+}
+
+func (h *testTracerHandler) HandleTracerNewChildEvent(pid int, childPid int, cloneFlags uint64) {}
+
+func (h *testTracerHandler) HandleTracerExecEvent(pid int, formerPid int) {}
+
+func (h *testTracerHandler) HandleTracerSyscallEnterEvent(pid int, curr *Syscall) bool {
+	if h.onEnter != nil {
+		h.onEnter(pid, curr)
+	}
+	return true
+}
+
+func (h *testTracerHandler) HandleTracerSyscallLeaveEvent(pid int, curr *Syscall, prev *Syscall) bool {
+	if h.onLeave != nil {
+		h.onLeave(pid, curr, prev)
+	}
+	return true
+}
+
+func TestReadMemoryExitedTracee(t *testing.T) {
+	var cmd = exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Skip(err)
+	}
+
+	var former = atomic.LoadInt32(&memReadMethod)
+	defer atomic.StoreInt32(&memReadMethod, former)
+	for method := probeMemReadMethod(); method <= memReadProcMem; method++ {
+		atomic.StoreInt32(&memReadMethod, method)
+		if err := readMemory(cmd.Process.Pid, 0x1000, make([]byte, 8)); err == nil {
+			t.Fatalf("method %d: read the memory of an exited process", method)
+		}
+		if v := atomic.LoadInt32(&memReadMethod); v != method {
+			t.Fatalf("method %d: downgraded to %d by an exited process", method, v)
+		}
+	}
+}
+
+// benchmarkOpenHeavy traces a program opening files in a loop, the path of each open is read like the fsfilter
+func benchmarkOpenHeavy(b *testing.B, method int32) {
+	var prog = buildTestProg(b, "open_heavy")
+
+	if probeMemReadMethod() > method {
+		b.Skip("the read method is not supported")
+	}
+	var former = atomic.LoadInt32(&memReadMethod)
+	defer atomic.StoreInt32(&memReadMethod, former)
+
+	var opens int
+	var handler = newTestTracerHandler()
+	handler.onEnter = func(pid int, curr *Syscall) {
+		if curr.GetNR() != SYS_OPENAT {
+			return
+		}
+		if err := curr.ReadArgs(); err != nil {
+			b.Fatal(err)
+		}
+		opens += 1
+	}
+
+	var t = time.Now()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		atomic.StoreInt32(&memReadMethod, method)
+		traceTestProg(b, handler, prog, "1000")
+	}
+	b.StopTimer()
+
+	if handler.err != nil {
+		b.Fatal(handler.err)
+	}
+	b.ReportMetric(float64(time.Since(t).Nanoseconds())/float64(opens), "ns/open")
+}
+
+func BenchmarkOpenHeavyProcessVMReadv(b *testing.B) {
+	benchmarkOpenHeavy(b, memReadProcessVMReadv)
+}
+
+func BenchmarkOpenHeavyProcMem(b *testing.B) {
+	benchmarkOpenHeavy(b, memReadProcMem)
+}
+
+func BenchmarkOpenHeavyPeekData(b *testing.B) {
+	benchmarkOpenHeavy(b, memReadPeekData)
+}
@@ -2,9 +2,10 @@ package ptrace
 
 import (
 	"fmt"
+	"strings"
 	"syscall"
+	"unsafe"
 
-	"github.com/seccomp/libseccomp-golang"
 	"golang.org/x/sys/unix"
 )
 
@@ -14,16 +15,70 @@ type ParamType int
 // Syscall param - all available values
 //go:generate stringer -type=ParamType -output=syscall_paramtype_string.go
 const (
-	ParamTypeAny          ParamType = iota // placeholder
-	ParamTypeInt                           // int
-	ParamTypePath                          // a pointer to char* path
-	ParamTypePipeFd                        // int pipefd[2]
-	ParamTypeFd                            // int fd
-	ParamTypeFlagOpen                      // flag for #open
-	ParamTypeFlagFnctlCmd                  // cmd for #fnctl
+	ParamTypeAny                ParamType = iota // placeholder
+	ParamTypeInt                                 // int
+	ParamTypePath                                // a pointer to char* path
+	ParamTypePipeFd                              // int pipefd[2]
+	ParamTypeFd                                  // int fd
+	ParamTypeFlagOpen                            // flag for #open
+	ParamTypeFlagFnctlCmd                        // cmd for #fnctl
+	ParamTypeString                              // a pointer to char* string
+	ParamTypeOpenHow                             // a pointer to struct open_how
+	ParamTypeMsghdr                              // a pointer to struct msghdr
+	ParamTypeFlagClone                           // flags for #clone, #unshare
+	ParamTypeCloneArgs                           // a pointer to struct clone_args
+	ParamTypeArgv                                // char *const argv[]
+	ParamTypeEnvp                                // char *const envp[]
+	ParamTypeSignal                              // int sig
+	ParamTypeFlagMmapProt                        // prot for #mmap, #mprotect
+	ParamTypeFlagMmap                            // flags for #mmap
+	ParamTypeFlagIoctlReq                        // request for #ioctl
+	ParamTypeFlagAccessMode                      // mode for #access
+	ParamTypeFlagAt                              // AT_* flags for #*at
+	ParamTypeFlagSeekWhence                      // whence for #lseek
+	ParamTypeFlagRlimitResource                  // resource for #getrlimit, #prlimit64
+	ParamTypeFlagFutexOp                         // futex_op for #futex
+	ParamTypeMode                                // mode_t mode
+	ParamTypeAddr                                // void *addr
+	ParamTypeSize                                // size_t size
+	ParamTypeStat                                // a pointer to struct stat
+	ParamTypeTimespec                            // a pointer to struct timespec
+	ParamTypeIovec                               // a pointer to struct iovec[], followed by int iovcnt
+	ParamTypeBuffer                              // a pointer to void* buf, followed by size_t count
+	ParamTypeUint                                // unsigned int, e.g. uid_t
+	ParamTypeLong                                // long, unsigned long, e.g. off_t
 	// ...
 )
 
+// Syscall param - the bits of the param read by the kernel, and the value is signed or not, e.g. the kernel
+// truncates the register of an int param to 32 bits
+func (t ParamType) Width() (int, bool) {
+	switch t {
+	case ParamTypeInt, ParamTypeFd, ParamTypeSignal, ParamTypeFlagOpen, ParamTypeFlagAccessMode, ParamTypeFlagAt, ParamTypeFlagFutexOp:
+		return 32, true
+	case ParamTypeUint, ParamTypeFlagFnctlCmd, ParamTypeFlagIoctlReq, ParamTypeFlagSeekWhence, ParamTypeFlagRlimitResource:
+		return 32, false
+	case ParamTypeMode: // umode_t
+		return 16, false
+	default:
+		return 64, false
+	}
+}
+
+// Syscall param - the type of the param at pos of the syscall named `name`, ParamTypeAny if unknown
+func LookupParamType(name string, pos int) ParamType {
+	if nr, ok := sysnumTable[name]; !ok {
+		return ParamTypeAny
+	} else if sig, ok := syscallTable[nr]; !ok || pos < 0 || pos >= len(sig.params) {
+		return ParamTypeAny
+	} else {
+		return sig.params[pos]
+	}
+}
+
+// Syscall param - max bytes of ParamTypeBuffer to read by default, e.g. the data passed to #write
+const DEFAULT_MAX_BUFFER_PREVIEW = 32
+
 // Syscall func signature
 type SyscallSignature struct {
 	name   string
@@ -41,19 +96,39 @@ type SyscallArg struct {
 	pos     int      // position in func
 
 	// hold ANY value, available after a call to #read
-	v_int       int
-	v_str       string
-	v_int_array []int
+	v_int        int
+	v_str        string
+	v_int_array  []int
+	v_str_array  []string
+	v_clone_args *CloneArgs
+	v_addr       uintptr // a pointer to the struct, kept if the struct is not decoded
+}
+
+// Syscall arg - struct clone_args, see clone3(2)
+const sizeofCloneArgs = unix.CLONE_ARGS_SIZE_VER2
+
+type CloneArgs struct {
+	Flags      uint64 // flags bit mask
+	PidFd      uint64 // where to store PID file descriptor (int *)
+	ChildTid   uint64 // where to store child TID, in child's memory (pid_t *)
+	ParentTid  uint64 // where to store child TID, in parent's memory (pid_t *)
+	ExitSignal uint64 // signal to deliver to parent on child termination
+	Stack      uint64 // pointer to lowest byte of stack
+	StackSize  uint64 // size of stack
+	Tls        uint64 // location of new TLS
+	SetTid     uint64 // pointer to a pid_t array (since Linux 5.5)
+	SetTidSize uint64 // number of elements in set_tid (since Linux 5.5)
+	Cgroup     uint64 // file descriptor for target cgroup of child (since Linux 5.7)
 }
 
 // Syscall func - interface Stringer
 func (a *SyscallArg) String() string {
 	var paramType = a.syscall.signature.params[a.pos]
 	switch paramType {
-	case ParamTypeInt:
+	case ParamTypeInt, ParamTypeUint, ParamTypeLong:
 		return fmt.Sprintf("%d", a.GetInt())
 	case ParamTypePath:
-		return fmt.Sprintf("'%s'", a.GetPath())
+		return fmt.Sprintf("%q", a.GetPath())
 	case ParamTypePipeFd:
 		var pipefd = a.GetPipeFd()
 		return fmt.Sprintf("[%d,%d]", pipefd[0], pipefd[1])
@@ -63,6 +138,84 @@ func (a *SyscallArg) String() string {
 		return FlagOpen(a.GetFlag()).String()
 	case ParamTypeFlagFnctlCmd:
 		return FlagFcntlCmd(a.GetFlag()).String()
+	case ParamTypeString:
+		return fmt.Sprintf("%q", a.GetString())
+	case ParamTypeOpenHow:
+		var how = a.v_int_array
+		return fmt.Sprintf("{flags=%s, mode=%#o, resolve=%#x}", FlagOpen(how[0]).String(), how[1], how[2])
+	case ParamTypeMsghdr:
+		return fmt.Sprintf("{msg_flags=%#x, SCM_RIGHTS=%v}", a.GetInt(), a.GetScmRights())
+	case ParamTypeFlagClone:
+		return FlagClone(uint64(a.GetFlag())).String()
+	case ParamTypeCloneArgs:
+		var args = a.GetCloneArgs()
+		var str = fmt.Sprintf("{flags=%s, exit_signal=%s", FlagClone(args.Flags), unix.SignalName(syscall.Signal(args.ExitSignal)))
+		if args.Flags&unix.CLONE_INTO_CGROUP != 0 {
+			str += fmt.Sprintf(", cgroup=%d", args.Cgroup)
+		}
+		return str + "}"
+	case ParamTypeEnvp:
+		return fmt.Sprintf("/* %d vars */", len(a.GetStringArray()))
+	case ParamTypeArgv:
+		var strs = make([]string, len(a.GetStringArray()))
+		for i, v := range a.GetStringArray() {
+			strs[i] = fmt.Sprintf("%q", v)
+		}
+		return "[" + strings.Join(strs, ", ") + "]"
+	case ParamTypeSignal:
+		return Signal(a.GetInt()).String()
+	case ParamTypeFlagMmapProt:
+		return FlagMmapProt(a.GetFlag()).String()
+	case ParamTypeFlagMmap:
+		return FlagMmap(a.GetFlag()).String()
+	case ParamTypeFlagIoctlReq:
+		return FlagIoctlReq(a.GetFlag()).String()
+	case ParamTypeFlagAccessMode:
+		return FlagAccessMode(a.GetFlag()).String()
+	case ParamTypeFlagAt:
+		return FlagAt(a.GetFlag()).String()
+	case ParamTypeFlagSeekWhence:
+		return FlagSeekWhence(a.GetFlag()).String()
+	case ParamTypeFlagRlimitResource:
+		return FlagRlimitResource(a.GetFlag()).String()
+	case ParamTypeFlagFutexOp:
+		return FlagFutexOp(a.GetFlag()).String()
+	case ParamTypeMode:
+		return Mode(a.GetInt()).String()
+	case ParamTypeSize:
+		return fmt.Sprint(uint(a.GetInt()))
+	case ParamTypeAddr:
+		return a.stringifyAddr()
+	case ParamTypeStat:
+		if a.v_int_array == nil {
+			return a.stringifyAddr()
+		}
+		return fmt.Sprintf("{st_mode=%s, st_size=%d}", FileMode(a.v_int_array[0]), a.v_int_array[1])
+	case ParamTypeTimespec:
+		if a.v_int_array == nil {
+			return a.stringifyAddr()
+		}
+		return fmt.Sprintf("{tv_sec=%d, tv_nsec=%d}", a.v_int_array[0], a.v_int_array[1])
+	case ParamTypeIovec:
+		if a.v_int_array == nil {
+			return a.stringifyAddr()
+		}
+		var strs = make([]string, len(a.v_int_array))
+		for i, v := range a.v_int_array {
+			strs[i] = fmt.Sprintf("{iov_len=%d}", v)
+		}
+		if a.GetInt() > len(a.v_int_array) {
+			strs = append(strs, "...")
+		}
+		return "[" + strings.Join(strs, ", ") + "]"
+	case ParamTypeBuffer:
+		if a.v_addr != 0 && a.v_str == "" && a.GetInt() != 0 {
+			return a.stringifyAddr()
+		}
+		if a.GetInt() > len(a.v_str) {
+			return fmt.Sprintf("%q...", a.v_str)
+		}
+		return fmt.Sprintf("%q", a.v_str)
 	default:
 		return "<any>"
 	}
@@ -78,11 +231,31 @@ func (a *SyscallArg) GetPath() string {
 	return a.v_str
 }
 
+// Syscall arg - convert value to String
+func (a *SyscallArg) GetString() string {
+	return a.v_str
+}
+
+// Syscall arg - convert value to String array, e.g. argv, envp
+func (a *SyscallArg) GetStringArray() []string {
+	return a.v_str_array
+}
+
 // Syscall arg - convert value to PipeFd
 func (a *SyscallArg) GetPipeFd() []int {
 	return a.v_int_array
 }
 
+// Syscall arg - convert value to SCM_RIGHTS fds carried by a msghdr
+func (a *SyscallArg) GetScmRights() []int {
+	return a.v_int_array
+}
+
+// Syscall arg - convert value to CloneArgs
+func (a *SyscallArg) GetCloneArgs() *CloneArgs {
+	return a.v_clone_args
+}
+
 // Syscall arg - convert value to Fd
 func (a *SyscallArg) GetFd() int {
 	return a.v_int
@@ -93,11 +266,29 @@ func (a *SyscallArg) GetFlag() int {
 	return a.v_int
 }
 
+// Syscall arg - convert value to the leading bytes of a buffer, plz see Syscall#SetMaxBufferPreview
+func (a *SyscallArg) GetBuffer() []byte {
+	return []byte(a.v_str)
+}
+
+// Syscall arg - helper for stringify a pointer
+func (a *SyscallArg) stringifyAddr() string {
+	if a.v_addr == 0 {
+		return "NULL"
+	}
+	return fmt.Sprintf("%#x", a.v_addr)
+}
+
 // Syscall arg - check param type
 func (a *SyscallArg) IsParamType(t ParamType) bool {
 	return a.syscall.signature.params[a.pos] == t
 }
 
+// Syscall arg - check the pointer value is NULL
+func (a *SyscallArg) IsNil() bool {
+	return a.syscall.getArgReg(a.pos) == 0
+}
+
 // Syscall arg - read value from register
 func (a *SyscallArg) read() error {
 	var paramType = a.syscall.signature.params[a.pos]
@@ -110,18 +301,83 @@ func (a *SyscallArg) read() error {
 		} else {
 			a.v_str = v
 		}
+	case ParamTypeString:
+		if v, err := a.readString(regptr, unix.PathMax); err != nil {
+			return err
+		} else {
+			a.v_str = v
+		}
 	case ParamTypePipeFd:
 		if v, err := a.readIntArray(regptr, 2); err != nil {
 			return err
 		} else {
 			a.v_int_array = v
 		}
+	case ParamTypeOpenHow:
+		if v, err := a.readUint64Array(regptr, 3); err != nil {
+			return err
+		} else {
+			a.v_int = v[0] // flags
+			a.v_int_array = v
+		}
+	case ParamTypeMsghdr:
+		if flags, fds, err := a.readMsghdr(regptr); err != nil {
+			return err
+		} else {
+			a.v_int = flags
+			a.v_int_array = fds
+		}
+	case ParamTypeArgv, ParamTypeEnvp:
+		if v, err := a.readStringArray(regptr, maxStringArrayLen); err != nil {
+			return err
+		} else {
+			a.v_str_array = v
+		}
+	case ParamTypeCloneArgs:
+		if v, err := a.readCloneArgs(regptr, int(a.syscall.getArgReg(a.pos+1))); err != nil {
+			return err
+		} else {
+			a.v_int = int(v.Flags)
+			a.v_clone_args = v
+		}
+	case ParamTypeStat:
+		a.v_addr = regptr
+		a.v_int_array = a.readStat(regptr)
+	case ParamTypeTimespec:
+		a.v_addr = regptr
+		a.v_int_array = a.readTimespec(regptr)
+	case ParamTypeIovec:
+		a.v_addr = regptr
+		a.v_int = int(int32(a.syscall.getArgReg(a.pos + 1)))
+		a.v_int_array = a.readIovec(regptr, a.v_int)
+	case ParamTypeBuffer:
+		a.v_addr = regptr
+		a.v_int = int(a.syscall.getArgReg(a.pos + 1))
+		a.v_str = string(a.readBuffer(regptr, a.v_int))
+	case ParamTypeAddr, ParamTypeSize:
+		a.v_addr = regptr
+		a.v_int = int(regptr)
+	case ParamTypeLong:
+		a.v_int = int(regptr)
+	case ParamTypeUint, ParamTypeFlagIoctlReq, ParamTypeMode:
+		a.v_int = int(uint32(regptr))
 	case
 		ParamTypeInt,
 		ParamTypeFd,
-		ParamTypeFlagOpen,
-		ParamTypeFlagFnctlCmd:
+		ParamTypeFlagFnctlCmd,
+		ParamTypeSignal,
+		ParamTypeFlagMmapProt,
+		ParamTypeFlagMmap,
+		ParamTypeFlagAccessMode,
+		ParamTypeFlagAt,
+		ParamTypeFlagSeekWhence,
+		ParamTypeFlagRlimitResource,
+		ParamTypeFlagFutexOp:
 		a.v_int = int(int32(regptr))
+	case ParamTypeFlagOpen: // O_LARGEFILE is implied on 64-bit, but passed explicitly by 32-bit programs
+		a.v_int = int(int32(regptr)) &^ a.syscall.abi.oLargefile
+	case ParamTypeFlagClone:
+		a.v_int = int(regptr)
 	}
 
 	return nil
@@ -133,26 +389,53 @@ func (a *SyscallArg) readString(addr uintptr, max int) (string, error) {
 		return "<nil>", nil
 	}
 
-	var str string
-	var buf [1]byte
-	for len(str) < max {
-		if _, err := syscall.PtracePeekData(a.syscall.pid, addr, buf[:]); err != nil {
-			return "", fmt.Errorf("PeekData: %s", err.Error())
+	return readMemoryString(a.syscall.pid, addr, max)
+}
+
+// Syscall arg - helper for read NULL-terminated array of pointers to null-terminated string
+const (
+	maxStringArrayLen = 1 << 16   // the number of entries, the total size is also limited by the kernel, ARG_MAX
+	maxArgStrlen      = 32 * 4096 // the size of an entry, including the NULL, MAX_ARG_STRLEN
+)
+
+func (a *SyscallArg) readStringArray(addr uintptr, max int) ([]string, error) {
+	if addr == 0 {
+		return nil, nil
+	}
+
+	var strs []string
+	var wordSize = a.syscall.abi.wordSize
+	var buf = make([]byte, wordSize)
+	for {
+		if err := readMemory(a.syscall.pid, addr, buf); err != nil {
+			return nil, err
 		}
-		if buf[0] == 0 { // NULL
-			break
+		var ptr uintptr
+		if wordSize == 4 {
+			ptr = uintptr(nativeEndian.Uint32(buf))
+		} else {
+			ptr = uintptr(nativeEndian.Uint64(buf))
+		}
+		if ptr == 0 { // NULL
+			return strs, nil
+		}
+		if len(strs) == max {
+			return nil, fmt.Errorf("%w: array exceeds %d entries", ErrTooLong, max)
+		}
+		if str, err := a.readString(ptr, maxArgStrlen); err != nil {
+			return nil, err
+		} else {
+			strs = append(strs, str)
 		}
-		str = str + string(buf[:])
-		addr++
+		addr += uintptr(wordSize)
 	}
-	return str, nil
 }
 
 // Syscall arg - helper for read int array
 func (a *SyscallArg) readIntArray(addr uintptr, size int) ([]int, error) {
 	var buf = make([]byte, size*4)
-	if _, err := syscall.PtracePeekData(a.syscall.pid, addr, buf[:]); err != nil {
-		return nil, fmt.Errorf("PeekData: %s", err.Error())
+	if err := readMemory(a.syscall.pid, addr, buf[:]); err != nil {
+		return nil, err
 	}
 
 	var val = make([]int, size)
@@ -162,6 +445,228 @@ func (a *SyscallArg) readIntArray(addr uintptr, size int) ([]int, error) {
 	return val, nil
 }
 
+// Syscall arg - helper for read uint64 array, e.g. struct open_how
+func (a *SyscallArg) readUint64Array(addr uintptr, size int) ([]int, error) {
+	var val = make([]int, size)
+	if addr == 0 {
+		return val, nil
+	}
+
+	var buf = make([]byte, size*8)
+	if err := readMemory(a.syscall.pid, addr, buf[:]); err != nil {
+		return nil, err
+	}
+
+	for i := range val {
+		val[i] = int(nativeEndian.Uint64(buf[i*8 : (i+1)*8]))
+	}
+	return val, nil
+}
+
+// Syscall arg - helper for read struct msghdr, returns msg_flags and fds passed via SCM_RIGHTS
+//
+// Only meaningful when the syscall leaves, e.g. recvmsg, the kernel updates
+// msg_controllen to the length of the control messages actually received.
+func (a *SyscallArg) readMsghdr(addr uintptr) (int, []int, error) {
+	if addr == 0 {
+		return 0, nil, nil
+	}
+
+	var control uintptr
+	var controllen, flags int
+	if a.syscall.abi.compat { // struct compat_msghdr
+		var hdr = make([]byte, sizeofCompatMsghdr)
+		if err := readMemory(a.syscall.pid, addr, hdr); err != nil {
+			return 0, nil, err
+		}
+		control = uintptr(nativeEndian.Uint32(hdr[offsetofCompatMsgControl:]))
+		controllen = int(nativeEndian.Uint32(hdr[offsetofCompatMsgControllen:]))
+		flags = int(int32(nativeEndian.Uint32(hdr[offsetofCompatMsgFlags:])))
+	} else {
+		var hdr = make([]byte, sizeofMsghdr)
+		if err := readMemory(a.syscall.pid, addr, hdr); err != nil {
+			return 0, nil, err
+		}
+		control = uintptr(nativeEndian.Uint64(hdr[offsetofMsgControl:]))
+		controllen = int(nativeEndian.Uint64(hdr[offsetofMsgControllen:]))
+		flags = int(int32(nativeEndian.Uint32(hdr[offsetofMsgFlags:])))
+	}
+	if control == 0 || controllen <= 0 {
+		return flags, nil, nil
+	}
+	if controllen > maxMsgControllen {
+		controllen = maxMsgControllen
+	}
+
+	var buf = make([]byte, controllen)
+	if err := readMemory(a.syscall.pid, control, buf); err != nil {
+		return 0, nil, err
+	}
+
+	if a.syscall.abi.compat {
+		return flags, parseCompatScmRights(buf), nil
+	}
+
+	var fds []int
+	var msgs, err = unix.ParseSocketControlMessage(buf)
+	if err != nil { // garbage, e.g. a control buffer not filled by kernel yet
+		return flags, nil, nil
+	}
+	for i := range msgs {
+		if msgs[i].Header.Level != unix.SOL_SOCKET || msgs[i].Header.Type != unix.SCM_RIGHTS {
+			continue
+		}
+		if v, err := unix.ParseUnixRights(&msgs[i]); err == nil {
+			fds = append(fds, v...)
+		}
+	}
+	return flags, fds, nil
+}
+
+// Memory Layout - struct compat_msghdr, struct compat_cmsghdr, the same on all 32-bit ABIs
+const (
+	sizeofCompatMsghdr          = 28 // msg_name, msg_namelen, msg_iov, msg_iovlen, msg_control, msg_controllen, msg_flags
+	offsetofCompatMsgControl    = 16
+	offsetofCompatMsgControllen = 20
+	offsetofCompatMsgFlags      = 24
+	sizeofCompatMmsghdr         = 32 // struct compat_msghdr msg_hdr, msg_len
+	sizeofCompatCmsghdr         = 12 // cmsg_len, cmsg_level, cmsg_type, aligned to 4 bytes
+)
+
+// parseCompatScmRights returns the fds of SCM_RIGHTS in the control buffer of struct compat_msghdr
+func parseCompatScmRights(buf []byte) []int {
+	var fds []int
+	for len(buf) >= sizeofCompatCmsghdr {
+		var cmsgLen = int(nativeEndian.Uint32(buf[0:]))
+		var level = int32(nativeEndian.Uint32(buf[4:]))
+		var typ = int32(nativeEndian.Uint32(buf[8:]))
+		if cmsgLen < sizeofCompatCmsghdr || cmsgLen > len(buf) { // garbage, e.g. a control buffer not filled by kernel yet
+			break
+		}
+		if level == unix.SOL_SOCKET && typ == unix.SCM_RIGHTS {
+			for data := buf[sizeofCompatCmsghdr:cmsgLen]; len(data) >= 4; data = data[4:] {
+				fds = append(fds, int(int32(nativeEndian.Uint32(data))))
+			}
+		}
+
+		var next = (cmsgLen + 3) &^ 3
+		if next > len(buf) {
+			break
+		}
+		buf = buf[next:]
+	}
+	return fds
+}
+
+// Syscall arg - helper for read struct stat, returns st_mode and st_size, or nil if not decoded
+//
+// Only meaningful when the syscall leaves, the struct is filled by kernel.
+func (a *SyscallArg) readStat(addr uintptr) []int {
+	if addr == 0 || a.syscall.abi.compat { // struct stat64, struct compat_stat
+		return nil
+	}
+
+	var st unix.Stat_t
+	var buf = (*[unsafe.Sizeof(st)]byte)(unsafe.Pointer(&st))[:]
+	if err := readMemory(a.syscall.pid, addr, buf); err != nil {
+		return nil
+	}
+	return []int{int(st.Mode), int(st.Size)}
+}
+
+// Syscall arg - helper for read struct timespec, returns tv_sec and tv_nsec, or nil if not decoded
+func (a *SyscallArg) readTimespec(addr uintptr) []int {
+	if addr == 0 {
+		return nil
+	}
+
+	var size = a.syscall.abi.wordSize // time_t, long
+	if a.syscall.abi.name == ArchX32 {
+		size = 8
+	}
+	var buf = make([]byte, size*2)
+	if err := readMemory(a.syscall.pid, addr, buf); err != nil {
+		return nil
+	}
+	if size == 4 {
+		return []int{int(int32(nativeEndian.Uint32(buf))), int(int32(nativeEndian.Uint32(buf[4:])))}
+	}
+	return []int{int(int64(nativeEndian.Uint64(buf))), int(int64(nativeEndian.Uint64(buf[8:])))}
+}
+
+// Syscall arg - helper for read struct iovec[], returns the iov_len of leading `maxIovecLen` ones, or nil if not decoded
+const maxIovecLen = 16
+
+func (a *SyscallArg) readIovec(addr uintptr, count int) []int {
+	if addr == 0 || count < 0 {
+		return nil
+	}
+	if count > maxIovecLen {
+		count = maxIovecLen
+	}
+
+	var wordSize = a.syscall.abi.wordSize
+	var buf = make([]byte, count*wordSize*2)
+	if err := readMemory(a.syscall.pid, addr, buf); err != nil {
+		return nil
+	}
+
+	var lens = make([]int, count)
+	for i := range lens {
+		var off = (i*2 + 1) * wordSize // iov_base, iov_len
+		if wordSize == 4 {
+			lens[i] = int(nativeEndian.Uint32(buf[off:]))
+		} else {
+			lens[i] = int(nativeEndian.Uint64(buf[off:]))
+		}
+	}
+	return lens
+}
+
+// Syscall arg - helper for read the leading `maxBufferPreview` bytes of a buffer, returns nil if not decoded
+func (a *SyscallArg) readBuffer(addr uintptr, count int) []byte {
+	if addr == 0 || count <= 0 {
+		return nil
+	}
+	if max := a.syscall.maxBufferPreview; count > max {
+		count = max
+	}
+
+	var buf = make([]byte, count)
+	if err := readMemory(a.syscall.pid, addr, buf); err != nil {
+		return nil
+	}
+	return buf
+}
+
+// Syscall arg - helper for read struct clone_args, `size` is the size of the struct in tracee
+func (a *SyscallArg) readCloneArgs(addr uintptr, size int) (*CloneArgs, error) {
+	var v = &CloneArgs{}
+	if addr == 0 {
+		return v, nil
+	}
+	if size > sizeofCloneArgs {
+		size = sizeofCloneArgs
+	}
+	if size < unix.CLONE_ARGS_SIZE_VER0 {
+		return nil, fmt.Errorf("invalid clone_args size(%d)", size)
+	}
+
+	var buf = make([]byte, sizeofCloneArgs) // zero-filled if smaller, e.g. CLONE_ARGS_SIZE_VER0
+	if err := readMemory(a.syscall.pid, addr, buf[:size]); err != nil {
+		return nil, err
+	}
+
+	var fields = []*uint64{
+		&v.Flags, &v.PidFd, &v.ChildTid, &v.ParentTid, &v.ExitSignal, &v.Stack,
+		&v.StackSize, &v.Tls, &v.SetTid, &v.SetTidSize, &v.Cgroup,
+	}
+	for i, field := range fields {
+		*field = nativeEndian.Uint64(buf[i*8 : (i+1)*8])
+	}
+	return v, nil
+}
+
 // Syscall retval
 type SyscallRetval struct {
 	syscall *Syscall      // pointer to syscall func
@@ -171,6 +676,9 @@ type SyscallRetval struct {
 
 // Syscall retval - interface Stringer
 func (r *SyscallRetval) String() string {
+	if name, ok := restartErrnoNames[r.errno]; ok && r.HasError() {
+		return fmt.Sprintf("%d, %s", r.value, name)
+	}
 	if r.HasError() {
 		return fmt.Sprintf("%d, %s", r.value, r.errno.Error())
 	} else {
@@ -188,9 +696,34 @@ func (r *SyscallRetval) GetErrno() syscall.Errno {
 	return r.errno
 }
 
-// Syscall retval - check errno
+// Syscall retval - errno name, e.g. ENOENT
+func (r *SyscallRetval) GetErrnoName() string {
+	if name, ok := restartErrnoNames[r.errno]; ok {
+		return name[:strings.IndexByte(name, ' ')]
+	}
+	if name := unix.ErrnoName(r.errno); name != "" {
+		return name
+	}
+	return fmt.Sprintf("errno %d", int(r.errno))
+}
+
+// Syscall retval - errno name and description, e.g. ENOENT (No such file or directory)
+func (r *SyscallRetval) GetErrnoDesc() string {
+	if name, ok := restartErrnoNames[r.errno]; ok {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", r.GetErrnoName(), r.errno.Error())
+}
+
+// Syscall retval - check errno, like IS_ERR_VALUE, e.g. mmap2 returns an address above 2 GiB for a 32-bit program
 func (r *SyscallRetval) HasError() bool {
-	return r.value < 0
+	return r.value < 0 && r.value >= -maxErrno
+}
+
+// Syscall retval - check the syscall is interrupted by a signal and will be restarted, e.g. ERESTARTSYS
+func (r *SyscallRetval) HasError_ERESTART() bool {
+	_, ok := restartErrnoNames[r.errno]
+	return ok && r.HasError()
 }
 
 // Syscall retval - check errno ENOSYS
@@ -198,35 +731,84 @@ func (r *SyscallRetval) HasError_ENOSYS() bool {
 	return r.value == -0x26
 }
 
+// Syscall retval - kernel internal errno, never seen by userspace, see linux/errno.h
+var restartErrnoNames = map[syscall.Errno]string{
+	512: "ERESTARTSYS (To be restarted if SA_RESTART is set)",
+	513: "ERESTARTNOINTR (To be restarted)",
+	514: "ERESTARTNOHAND (To be restarted if no handler)",
+	516: "ERESTART_RESTARTBLOCK (Interrupted by signal)",
+}
+
 // Syscall retval - read value from register
 func (r *SyscallRetval) read() error {
-	if r.value = r.syscall.getRetval(); r.value < 0 {
+	if r.value = r.syscall.getRetval(); r.HasError() {
 		r.errno = syscall.Errno(-r.value)
 	}
 	return nil
 }
 
+// Syscall retval - the max errno, see linux/err.h
+const maxErrno = 4095
+
+// Syscall retval - the retval of a compat syscall, sign-extended only if it's an errno
+func compatRetval(v uint32) int {
+	if int32(v) < 0 && int32(v) >= -maxErrno {
+		return int(int32(v))
+	}
+	return int(v)
+}
+
 // Syscall func
 type Syscall struct {
-	pid       int                // process id
-	regs      syscall.PtraceRegs // registers
-	nr        uint               // number
-	name      string             // name
-	signature SyscallSignature   // signature
-	args      []*SyscallArg      // arguments
-	retval    *SyscallRetval     // return value
+	pid       int              // process id
+	regs      ptraceRegs       // registers
+	abi       *syscallABI      // ABI, e.g. x86_64, i386
+	op        uint8            // stop kind, PTRACE_SYSCALL_INFO_NONE if PTRACE_GET_SYSCALL_INFO is not supported
+	nr        uint             // number
+	name      string           // name
+	signature SyscallSignature // signature
+	args      []*SyscallArg    // arguments
+	retval    *SyscallRetval   // return value
+
+	maxBufferPreview int // max bytes of ParamTypeBuffer to read
 }
 
-// Syscall func - attr reader for nr
+// Syscall func - attr reader for nr, the canonical number regardless of the ABI, e.g. SYS_OPENAT
 func (c *Syscall) GetNR() uint {
 	return c.nr
 }
 
+// Syscall func - attr reader for ABI name, e.g. x86_64, i386
+func (c *Syscall) GetArch() string {
+	return c.abi.name
+}
+
+// Syscall func - check it is a compat syscall, e.g. i386 on x86-64
+func (c *Syscall) IsCompat() bool {
+	return c.abi.compat
+}
+
 // Syscall func - attr reader for name
 func (c *Syscall) GetName() string {
 	return c.name
 }
 
+// Syscall func - attr writer for the max bytes of ParamTypeBuffer to read, must be called before ReadArgs
+func (c *Syscall) SetMaxBufferPreview(n int) {
+	c.maxBufferPreview = n
+}
+
+// Syscall func - attr reader for canonical name, i.e. the x86-64 name, e.g. lseek for _llseek of i386
+func (c *Syscall) GetCanonicalName() string {
+	if c.abi.canonical {
+		return c.name
+	}
+	if sig, ok := syscallTable[c.nr]; ok {
+		return sig.name
+	}
+	return c.name
+}
+
 // Syscall func - attr reader for args
 func (c *Syscall) GetArgs() []*SyscallArg {
 	return c.args
@@ -237,6 +819,11 @@ func (c *Syscall) GetArg(pos int) *SyscallArg {
 	return c.args[pos]
 }
 
+// Syscall func - raw value of arg in specified postion, like the one seen by seccomp(2)
+func (c *Syscall) GetArgValue(pos int) uint64 {
+	return uint64(c.getArgReg(pos))
+}
+
 // Syscall func - attr reader for retval
 func (c *Syscall) GetRetval() *SyscallRetval {
 	return c.retval
@@ -257,27 +844,125 @@ func (c *Syscall) ReadRetval() error {
 	return c.retval.read()
 }
 
+// Syscall func - read flags of clone, clone3, fork, vfork
+func (c *Syscall) ReadCloneFlags() (uint64, error) {
+	switch c.nr {
+	case SYS_CLONE:
+		return uint64(c.getArgReg(0)), nil
+	case SYS_CLONE3:
+		var arg = &SyscallArg{syscall: c, pos: 0}
+		if args, err := arg.readCloneArgs(c.getArgReg(0), int(c.getArgReg(1))); err != nil {
+			return 0, err
+		} else {
+			return args.Flags, nil
+		}
+	case SYS_VFORK:
+		return unix.CLONE_VM | unix.CLONE_VFORK | uint64(unix.SIGCHLD), nil
+	case SYS_FORK:
+		return uint64(unix.SIGCHLD), nil
+	default:
+		return 0, fmt.Errorf("ReadCloneFlags: unexpected syscall %s", c.name)
+	}
+}
+
+// Syscall func - read the pidfd stored by kernel, available when clone/clone3 with CLONE_PIDFD leave
 //
+// Call it on the Syscall read when it enters, the registers of args may be overwritten when it leaves, e.g. x0.
+func (c *Syscall) ReadPidFd() (int, error) {
+	var addr uintptr
+	switch c.nr {
+	case SYS_CLONE: // stored in parent_tid
+		addr = c.getArgReg(2)
+	case SYS_CLONE3:
+		var arg = &SyscallArg{syscall: c, pos: 0}
+		if args, err := arg.readCloneArgs(c.getArgReg(0), int(c.getArgReg(1))); err != nil {
+			return 0, err
+		} else {
+			addr = uintptr(args.PidFd)
+		}
+	default:
+		return 0, fmt.Errorf("ReadPidFd: unexpected syscall %s", c.name)
+	}
+
+	var buf = make([]byte, 4)
+	if err := readMemory(c.pid, addr, buf); err != nil {
+		return 0, err
+	}
+	return int(int32(nativeEndian.Uint32(buf))), nil
+}
+
+// Syscall func - read the fds passed via SCM_RIGHTS, available when recvmmsg leave, n is the number of messages received
+//
+// Call it on the Syscall read when it enters, the registers of args may be overwritten when it leaves, e.g. x0.
+func (c *Syscall) ReadMmsgScmRights(n int) ([]int, error) {
+	if c.nr != SYS_RECVMMSG {
+		return nil, fmt.Errorf("ReadMmsgScmRights: unexpected syscall %s", c.name)
+	}
+
+	var fds []int
+	var arg = &SyscallArg{syscall: c, pos: 1}
+	var addr = c.getArgReg(1)
+	var size = sizeofMmsghdr
+	if c.abi.compat {
+		size = sizeofCompatMmsghdr
+	}
+	for i := 0; i < n; i++ {
+		if _, v, err := arg.readMsghdr(addr + uintptr(i*size)); err != nil {
+			return nil, err
+		} else {
+			fds = append(fds, v...)
+		}
+	}
+	return fds, nil
+}
+
 func GetSyscall(pid int) (*Syscall, error) {
-	var regs = syscall.PtraceRegs{}
-	if err := syscall.PtraceGetRegs(pid, &regs); err != nil {
+	return getSyscall(pid, nil)
+}
+
+// getSyscall is GetSyscall, in is the syscall entered by the tracee, if any. At syscall-exit-stop, the nr is not
+// looked up from the regs, e.g. orig_rax is -1 when `rt_sigreturn` returns.
+func getSyscall(pid int, in *Syscall) (*Syscall, error) {
+	var regs = ptraceRegs{}
+	if err := getRegs(pid, &regs); err != nil {
 		return nil, fmt.Errorf("GetRegs: [%d] %s", pid, err.Error())
 	}
 
-	var nr = uint(regs.Orig_rax)
-	var name, err = seccomp.ScmpSyscall(nr).GetName()
+	var info, err = getSyscallInfo(pid)
 	if err != nil {
-		return nil, fmt.Errorf("ScmpGetName: %s", err.Error())
+		return nil, fmt.Errorf("GetSyscallInfo: [%d] %s", pid, err.Error())
+	}
+
+	var call = Syscall{pid: pid, regs: regs, op: unix.PTRACE_SYSCALL_INFO_NONE, maxBufferPreview: DEFAULT_MAX_BUFFER_PREVIEW}
+	if info != nil {
+		call.op = info.Op
 	}
 
+	var name string
+	var nr uint
 	var signature SyscallSignature
-	if sig, ok := syscallTable[nr]; ok {
-		signature = sig
+	if in != nil && (call.op == unix.PTRACE_SYSCALL_INFO_EXIT || call.op == unix.PTRACE_SYSCALL_INFO_NONE) {
+		call.abi, name, nr, signature = in.abi, in.name, in.nr, in.signature
 	} else {
-		signature = makeSyscallSignature(fmt.Sprintf("signature(%s) not implemented", name))
+		abi, err := getABI(pid, &regs, info)
+		if err != nil {
+			return nil, fmt.Errorf("GetABI: [%d] %s", pid, err.Error())
+		}
+
+		call.abi = abi
+		name, nr, err = abi.lookup(call.getNativeNR())
+		if err != nil {
+			return nil, err
+		}
+
+		if sig, ok := syscallTable[nr]; ok {
+			signature = sig
+		} else {
+			signature = makeSyscallSignature(fmt.Sprintf("signature(%s) not implemented", name))
+		}
 	}
 
-	var call = Syscall{pid: pid, regs: regs, nr: nr, name: name, signature: signature}
+	call.nr, call.name, call.signature = nr, name, signature
 	var args = make([]*SyscallArg, len(signature.params))
 	for i := range signature.params {
 		args[i] = &SyscallArg{syscall: &call, pos: i}
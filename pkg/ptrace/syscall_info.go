@@ -0,0 +1,40 @@
+package ptrace
+
+import (
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Syscall info - struct ptrace_syscall_info, see PTRACE_GET_SYSCALL_INFO in ptrace(2), since Linux 5.3
+type ptraceSyscallInfo struct {
+	Op                 uint8     // PTRACE_SYSCALL_INFO_*
+	_                  [3]uint8  // padding
+	Arch               uint32    // AUDIT_ARCH_*
+	InstructionPointer uint64    // CPU instruction pointer
+	StackPointer       uint64    // CPU stack pointer
+	Data               [8]uint64 // union of entry, exit, seccomp
+}
+
+// Syscall info - PTRACE_GET_SYSCALL_INFO is not supported by the kernel
+var syscallInfoUnsupported int32
+
+// Syscall info - read by PTRACE_GET_SYSCALL_INFO, returns nil if the kernel does not support it
+func getSyscallInfo(pid int) (*ptraceSyscallInfo, error) {
+	if atomic.LoadInt32(&syscallInfoUnsupported) != 0 {
+		return nil, nil
+	}
+
+	var info ptraceSyscallInfo
+	_, _, errno := syscall.Syscall6(syscall.SYS_PTRACE, unix.PTRACE_GET_SYSCALL_INFO, uintptr(pid), unsafe.Sizeof(info), uintptr(unsafe.Pointer(&info)), 0, 0)
+	if errno == syscall.EIO { // Linux < 5.3
+		atomic.StoreInt32(&syscallInfoUnsupported, 1)
+		return nil, nil
+	}
+	if errno != 0 {
+		return nil, errno
+	}
+	return &info, nil
+}
@@ -0,0 +1,85 @@
+package ptrace
+
+import (
+	"fmt"
+
+	"github.com/seccomp/libseccomp-golang"
+	"golang.org/x/sys/unix"
+)
+
+// Registers
+type ptraceRegs = unix.PtraceRegsArm64
+
+// Registers - NT_PRSTATUS, see linux/elf.h
+const ntPrstatus = 1
+
+// Registers - read by PTRACE_GETREGSET, PTRACE_GETREGS is not available on arm64
+func getRegs(pid int, regs *ptraceRegs) error {
+	return unix.PtraceGetRegSetArm64(pid, ntPrstatus, regs)
+}
+
+// ABI - all supported ABIs
+var (
+	abiAArch64 = &syscallABI{name: ArchAArch64, scmpArch: seccomp.ArchARM64, wordSize: 8}
+	abiARM     = &syscallABI{name: ArchARM, scmpArch: seccomp.ArchARM, wordSize: 4, compat: true, aliases: compat32Aliases, oLargefile: 0x20000}
+)
+
+// ABI - detect by PTRACE_GET_SYSCALL_INFO, or the processor state if not supported
+func getABI(pid int, regs *ptraceRegs, info *ptraceSyscallInfo) (*syscallABI, error) {
+	if info != nil {
+		switch info.Arch {
+		case auditArchAArch64:
+			return abiAArch64, nil
+		case auditArchARM:
+			return abiARM, nil
+		default:
+			return nil, fmt.Errorf("unsupported arch(%#x)", info.Arch)
+		}
+	}
+
+	if regs.Pstate&0x10 != 0 { // PSR_MODE32_BIT
+		return abiARM, nil
+	} else {
+		return abiAArch64, nil
+	}
+}
+
+// Calling Conventions
+func (c *Syscall) getNativeNR() uint {
+	if c.abi == abiARM {
+		return uint(uint32(c.regs.Regs[7]))
+	}
+	return uint(c.regs.Regs[8])
+}
+
+// Calling Conventions
+//
+// The x0 is overwritten by the return value when the syscall leaves, so arguments must be read when it enters.
+func (c *Syscall) getArgReg(pos int) uintptr {
+	if pos < 0 || pos > 5 {
+		panic(fmt.Sprintf("index out of range [%d] with length 6", pos))
+	}
+	if c.abi == abiARM {
+		return uintptr(uint32(c.regs.Regs[pos]))
+	}
+	return uintptr(c.regs.Regs[pos])
+}
+
+// Calling Conventions
+func (c *Syscall) getRetval() int {
+	if c.abi == abiARM {
+		return compatRetval(uint32(c.regs.Regs[0]))
+	}
+	return int(c.regs.Regs[0])
+}
+
+// Memory Layout - struct msghdr
+const (
+	sizeofMsghdr          = 56 // msg_name, msg_namelen, msg_iov, msg_iovlen, msg_control, msg_controllen, msg_flags
+	offsetofMsgControl    = 32
+	offsetofMsgControllen = 40
+	offsetofMsgFlags      = 48
+	sizeofMmsghdr         = 64 // struct msghdr msg_hdr, msg_len, padding
+
+	maxMsgControllen = 1 << 16 // enough for several SCM_MAX_FD(253) sized SCM_RIGHTS
+)
@@ -15,15 +15,41 @@ func _() {
 	_ = x[ParamTypeFd-4]
 	_ = x[ParamTypeFlagOpen-5]
 	_ = x[ParamTypeFlagFnctlCmd-6]
+	_ = x[ParamTypeString-7]
+	_ = x[ParamTypeOpenHow-8]
+	_ = x[ParamTypeMsghdr-9]
+	_ = x[ParamTypeFlagClone-10]
+	_ = x[ParamTypeCloneArgs-11]
+	_ = x[ParamTypeArgv-12]
+	_ = x[ParamTypeEnvp-13]
+	_ = x[ParamTypeSignal-14]
+	_ = x[ParamTypeFlagMmapProt-15]
+	_ = x[ParamTypeFlagMmap-16]
+	_ = x[ParamTypeFlagIoctlReq-17]
+	_ = x[ParamTypeFlagAccessMode-18]
+	_ = x[ParamTypeFlagAt-19]
+	_ = x[ParamTypeFlagSeekWhence-20]
+	_ = x[ParamTypeFlagRlimitResource-21]
+	_ = x[ParamTypeFlagFutexOp-22]
+	_ = x[ParamTypeMode-23]
+	_ = x[ParamTypeAddr-24]
+	_ = x[ParamTypeSize-25]
+	_ = x[ParamTypeStat-26]
+	_ = x[ParamTypeTimespec-27]
+	_ = x[ParamTypeIovec-28]
+	_ = x[ParamTypeBuffer-29]
+	_ = x[ParamTypeUint-30]
+	_ = x[ParamTypeLong-31]
 }
 
-const _ParamType_name = "ParamTypeAnyParamTypeIntParamTypePathParamTypePipeFdParamTypeFdParamTypeFlagOpenParamTypeFlagFnctlCmd"
+const _ParamType_name = "ParamTypeAnyParamTypeIntParamTypePathParamTypePipeFdParamTypeFdParamTypeFlagOpenParamTypeFlagFnctlCmdParamTypeStringParamTypeOpenHowParamTypeMsghdrParamTypeFlagCloneParamTypeCloneArgsParamTypeArgvParamTypeEnvpParamTypeSignalParamTypeFlagMmapProtParamTypeFlagMmapParamTypeFlagIoctlReqParamTypeFlagAccessModeParamTypeFlagAtParamTypeFlagSeekWhenceParamTypeFlagRlimitResourceParamTypeFlagFutexOpParamTypeModeParamTypeAddrParamTypeSizeParamTypeStatParamTypeTimespecParamTypeIovecParamTypeBufferParamTypeUintParamTypeLong"
 
-var _ParamType_index = [...]uint8{0, 12, 24, 37, 52, 63, 80, 101}
+var _ParamType_index = [...]uint16{0, 12, 24, 37, 52, 63, 80, 101, 116, 132, 147, 165, 183, 196, 209, 224, 245, 262, 283, 306, 321, 344, 371, 391, 404, 417, 430, 443, 460, 474, 489, 502, 515}
 
 func (i ParamType) String() string {
-	if i < 0 || i >= ParamType(len(_ParamType_index)-1) {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_ParamType_index)-1 {
 		return "ParamType(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
-	return _ParamType_name[_ParamType_index[i]:_ParamType_index[i+1]]
+	return _ParamType_name[_ParamType_index[idx]:_ParamType_index[idx+1]]
 }
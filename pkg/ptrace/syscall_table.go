@@ -0,0 +1,348 @@
+// Ref: https://chromium.googlesource.com/chromiumos/docs/+/HEAD/constants/syscalls.md
+
+package ptrace
+
+// Linux-4.14.0 System Call Table, indexed by canonical syscall numbers
+var syscallTable = map[uint]SyscallSignature{
+	SYS_READ:                   makeSyscallSignature("read", ParamTypeFd, ParamTypeAddr, ParamTypeSize),
+	SYS_WRITE:                  makeSyscallSignature("write", ParamTypeFd, ParamTypeBuffer, ParamTypeSize),
+	SYS_OPEN:                   makeSyscallSignature("open", ParamTypePath, ParamTypeFlagOpen, ParamTypeMode),
+	SYS_CLOSE:                  makeSyscallSignature("close", ParamTypeFd),
+	SYS_STAT:                   makeSyscallSignature("stat", ParamTypePath, ParamTypeStat),
+	SYS_FSTAT:                  makeSyscallSignature("fstat", ParamTypeFd, ParamTypeStat),
+	SYS_LSTAT:                  makeSyscallSignature("lstat", ParamTypePath, ParamTypeStat),
+	SYS_POLL:                   makeSyscallSignature("poll", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_LSEEK:                  makeSyscallSignature("lseek", ParamTypeFd, ParamTypeLong, ParamTypeFlagSeekWhence),
+	SYS_MMAP:                   makeSyscallSignature("mmap", ParamTypeAddr, ParamTypeSize, ParamTypeFlagMmapProt, ParamTypeFlagMmap, ParamTypeFd, ParamTypeLong),
+	SYS_MPROTECT:               makeSyscallSignature("mprotect", ParamTypeAddr, ParamTypeSize, ParamTypeFlagMmapProt),
+	SYS_MUNMAP:                 makeSyscallSignature("munmap", ParamTypeAddr, ParamTypeSize),
+	SYS_BRK:                    makeSyscallSignature("brk", ParamTypeAddr),
+	SYS_RT_SIGACTION:           makeSyscallSignature("rt_sigaction", ParamTypeSignal, ParamTypeAny, ParamTypeAny),
+	SYS_RT_SIGPROCMASK:         makeSyscallSignature("rt_sigprocmask", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_RT_SIGRETURN:           makeSyscallSignature("rt_sigreturn"),
+	SYS_IOCTL:                  makeSyscallSignature("ioctl", ParamTypeFd, ParamTypeFlagIoctlReq, ParamTypeAddr),
+	SYS_PREAD64:                makeSyscallSignature("pread64", ParamTypeFd, ParamTypeAddr, ParamTypeSize, ParamTypeLong),
+	SYS_PWRITE64:               makeSyscallSignature("pwrite64", ParamTypeFd, ParamTypeBuffer, ParamTypeSize, ParamTypeLong),
+	SYS_READV:                  makeSyscallSignature("readv", ParamTypeFd, ParamTypeIovec, ParamTypeLong),
+	SYS_WRITEV:                 makeSyscallSignature("writev", ParamTypeFd, ParamTypeIovec, ParamTypeLong),
+	SYS_ACCESS:                 makeSyscallSignature("access", ParamTypePath, ParamTypeFlagAccessMode),
+	SYS_PIPE:                   makeSyscallSignature("pipe", ParamTypePipeFd),
+	SYS_SELECT:                 makeSyscallSignature("select", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SCHED_YIELD:            makeSyscallSignature("sched_yield"),
+	SYS_MREMAP:                 makeSyscallSignature("mremap", ParamTypeAddr, ParamTypeSize, ParamTypeSize, ParamTypeLong, ParamTypeAddr),
+	SYS_MSYNC:                  makeSyscallSignature("msync", ParamTypeAddr, ParamTypeSize, ParamTypeInt),
+	SYS_MINCORE:                makeSyscallSignature("mincore", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_MADVISE:                makeSyscallSignature("madvise", ParamTypeAddr, ParamTypeSize, ParamTypeInt),
+	SYS_SHMGET:                 makeSyscallSignature("shmget", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SHMAT:                  makeSyscallSignature("shmat", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SHMCTL:                 makeSyscallSignature("shmctl", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_DUP:                    makeSyscallSignature("dup", ParamTypeFd),
+	SYS_DUP2:                   makeSyscallSignature("dup2", ParamTypeFd, ParamTypeFd),
+	SYS_PAUSE:                  makeSyscallSignature("pause"),
+	SYS_NANOSLEEP:              makeSyscallSignature("nanosleep", ParamTypeTimespec, ParamTypeTimespec),
+	SYS_GETITIMER:              makeSyscallSignature("getitimer", ParamTypeAny, ParamTypeAny),
+	SYS_ALARM:                  makeSyscallSignature("alarm", ParamTypeUint),
+	SYS_SETITIMER:              makeSyscallSignature("setitimer", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_GETPID:                 makeSyscallSignature("getpid"),
+	SYS_SENDFILE:               makeSyscallSignature("sendfile", ParamTypeFd, ParamTypeFd, ParamTypeAddr, ParamTypeSize),
+	SYS_SOCKET:                 makeSyscallSignature("socket", ParamTypeInt, ParamTypeInt, ParamTypeInt),
+	SYS_CONNECT:                makeSyscallSignature("connect", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_ACCEPT:                 makeSyscallSignature("accept", ParamTypeFd, ParamTypeAny, ParamTypeAny),
+	SYS_SENDTO:                 makeSyscallSignature("sendto", ParamTypeFd, ParamTypeBuffer, ParamTypeSize, ParamTypeUint, ParamTypeAddr, ParamTypeInt),
+	SYS_RECVFROM:               makeSyscallSignature("recvfrom", ParamTypeFd, ParamTypeAddr, ParamTypeSize, ParamTypeUint, ParamTypeAddr, ParamTypeAddr),
+	SYS_SENDMSG:                makeSyscallSignature("sendmsg", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_RECVMSG:                makeSyscallSignature("recvmsg", ParamTypeFd, ParamTypeMsghdr, ParamTypeUint),
+	SYS_SHUTDOWN:               makeSyscallSignature("shutdown", ParamTypeAny, ParamTypeAny),
+	SYS_BIND:                   makeSyscallSignature("bind", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_LISTEN:                 makeSyscallSignature("listen", ParamTypeAny, ParamTypeAny),
+	SYS_GETSOCKNAME:            makeSyscallSignature("getsockname", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_GETPEERNAME:            makeSyscallSignature("getpeername", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SOCKETPAIR:             makeSyscallSignature("socketpair", ParamTypeInt, ParamTypeInt, ParamTypeInt, ParamTypePipeFd),
+	SYS_SETSOCKOPT:             makeSyscallSignature("setsockopt", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_GETSOCKOPT:             makeSyscallSignature("getsockopt", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_CLONE:                  makeSyscallSignature("clone", ParamTypeFlagClone, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_FORK:                   makeSyscallSignature("fork"),
+	SYS_VFORK:                  makeSyscallSignature("vfork"),
+	SYS_EXECVE:                 makeSyscallSignature("execve", ParamTypePath, ParamTypeArgv, ParamTypeEnvp),
+	SYS_EXIT:                   makeSyscallSignature("exit", ParamTypeInt),
+	SYS_WAIT4:                  makeSyscallSignature("wait4", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_KILL:                   makeSyscallSignature("kill", ParamTypeInt, ParamTypeSignal),
+	SYS_UNAME:                  makeSyscallSignature("uname", ParamTypeAny),
+	SYS_SEMGET:                 makeSyscallSignature("semget", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SEMOP:                  makeSyscallSignature("semop", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SEMCTL:                 makeSyscallSignature("semctl", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SHMDT:                  makeSyscallSignature("shmdt", ParamTypeAny),
+	SYS_MSGGET:                 makeSyscallSignature("msgget", ParamTypeAny, ParamTypeAny),
+	SYS_MSGSND:                 makeSyscallSignature("msgsnd", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_MSGRCV:                 makeSyscallSignature("msgrcv", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_MSGCTL:                 makeSyscallSignature("msgctl", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_FCNTL:                  makeSyscallSignature("fcntl", ParamTypeFd, ParamTypeFlagFnctlCmd, ParamTypeLong),
+	SYS_FLOCK:                  makeSyscallSignature("flock", ParamTypeFd, ParamTypeUint),
+	SYS_FSYNC:                  makeSyscallSignature("fsync", ParamTypeFd),
+	SYS_FDATASYNC:              makeSyscallSignature("fdatasync", ParamTypeFd),
+	SYS_TRUNCATE:               makeSyscallSignature("truncate", ParamTypePath, ParamTypeAny),
+	SYS_FTRUNCATE:              makeSyscallSignature("ftruncate", ParamTypeFd, ParamTypeAny),
+	SYS_GETDENTS:               makeSyscallSignature("getdents", ParamTypeFd, ParamTypeAny, ParamTypeAny),
+	SYS_GETCWD:                 makeSyscallSignature("getcwd", ParamTypeAddr, ParamTypeSize),
+	SYS_CHDIR:                  makeSyscallSignature("chdir", ParamTypePath),
+	SYS_FCHDIR:                 makeSyscallSignature("fchdir", ParamTypeFd),
+	SYS_RENAME:                 makeSyscallSignature("rename", ParamTypePath, ParamTypePath),
+	SYS_MKDIR:                  makeSyscallSignature("mkdir", ParamTypePath, ParamTypeMode),
+	SYS_RMDIR:                  makeSyscallSignature("rmdir", ParamTypePath),
+	SYS_CREAT:                  makeSyscallSignature("creat", ParamTypePath, ParamTypeMode),
+	SYS_LINK:                   makeSyscallSignature("link", ParamTypePath, ParamTypePath),
+	SYS_UNLINK:                 makeSyscallSignature("unlink", ParamTypePath),
+	SYS_SYMLINK:                makeSyscallSignature("symlink", ParamTypePath, ParamTypePath),
+	SYS_READLINK:               makeSyscallSignature("readlink", ParamTypePath, ParamTypeAddr, ParamTypeSize),
+	SYS_CHMOD:                  makeSyscallSignature("chmod", ParamTypePath, ParamTypeMode),
+	SYS_FCHMOD:                 makeSyscallSignature("fchmod", ParamTypeFd, ParamTypeMode),
+	SYS_CHOWN:                  makeSyscallSignature("chown", ParamTypePath, ParamTypeAny, ParamTypeAny),
+	SYS_FCHOWN:                 makeSyscallSignature("fchown", ParamTypeFd, ParamTypeAny, ParamTypeAny),
+	SYS_LCHOWN:                 makeSyscallSignature("lchown", ParamTypePath, ParamTypeAny, ParamTypeAny),
+	SYS_UMASK:                  makeSyscallSignature("umask", ParamTypeMode),
+	SYS_GETTIMEOFDAY:           makeSyscallSignature("gettimeofday", ParamTypeAny, ParamTypeAny),
+	SYS_GETRLIMIT:              makeSyscallSignature("getrlimit", ParamTypeFlagRlimitResource, ParamTypeAny),
+	SYS_GETRUSAGE:              makeSyscallSignature("getrusage", ParamTypeAny, ParamTypeAny),
+	SYS_SYSINFO:                makeSyscallSignature("sysinfo", ParamTypeAny),
+	SYS_TIMES:                  makeSyscallSignature("times", ParamTypeAny),
+	SYS_PTRACE:                 makeSyscallSignature("ptrace", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_GETUID:                 makeSyscallSignature("getuid"),
+	SYS_SYSLOG:                 makeSyscallSignature("syslog", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_GETGID:                 makeSyscallSignature("getgid"),
+	SYS_SETUID:                 makeSyscallSignature("setuid", ParamTypeAny),
+	SYS_SETGID:                 makeSyscallSignature("setgid", ParamTypeAny),
+	SYS_GETEUID:                makeSyscallSignature("geteuid"),
+	SYS_GETEGID:                makeSyscallSignature("getegid"),
+	SYS_SETPGID:                makeSyscallSignature("setpgid", ParamTypeAny, ParamTypeAny),
+	SYS_GETPPID:                makeSyscallSignature("getppid"),
+	SYS_GETPGRP:                makeSyscallSignature("getpgrp"),
+	SYS_SETSID:                 makeSyscallSignature("setsid"),
+	SYS_SETREUID:               makeSyscallSignature("setreuid", ParamTypeAny, ParamTypeAny),
+	SYS_SETREGID:               makeSyscallSignature("setregid", ParamTypeAny, ParamTypeAny),
+	SYS_GETGROUPS:              makeSyscallSignature("getgroups", ParamTypeAny, ParamTypeAny),
+	SYS_SETGROUPS:              makeSyscallSignature("setgroups", ParamTypeAny, ParamTypeAny),
+	SYS_SETRESUID:              makeSyscallSignature("setresuid", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_GETRESUID:              makeSyscallSignature("getresuid", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SETRESGID:              makeSyscallSignature("setresgid", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_GETRESGID:              makeSyscallSignature("getresgid", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_GETPGID:                makeSyscallSignature("getpgid", ParamTypeAny),
+	SYS_SETFSUID:               makeSyscallSignature("setfsuid", ParamTypeAny),
+	SYS_SETFSGID:               makeSyscallSignature("setfsgid", ParamTypeAny),
+	SYS_GETSID:                 makeSyscallSignature("getsid", ParamTypeAny),
+	SYS_CAPGET:                 makeSyscallSignature("capget", ParamTypeAny, ParamTypeAny),
+	SYS_CAPSET:                 makeSyscallSignature("capset", ParamTypeAny, ParamTypeAny),
+	SYS_RT_SIGPENDING:          makeSyscallSignature("rt_sigpending", ParamTypeAny),
+	SYS_RT_SIGTIMEDWAIT:        makeSyscallSignature("rt_sigtimedwait", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_RT_SIGQUEUEINFO:        makeSyscallSignature("rt_sigqueueinfo", ParamTypeInt, ParamTypeSignal, ParamTypeAny),
+	SYS_RT_SIGSUSPEND:          makeSyscallSignature("rt_sigsuspend", ParamTypeAny),
+	SYS_SIGALTSTACK:            makeSyscallSignature("sigaltstack", ParamTypeAny, ParamTypeAny),
+	SYS_UTIME:                  makeSyscallSignature("utime", ParamTypePath, ParamTypeAny),
+	SYS_MKNOD:                  makeSyscallSignature("mknod", ParamTypePath, ParamTypeMode, ParamTypeUint),
+	SYS_USELIB:                 makeSyscallSignature("uselib", ParamTypeAny),
+	SYS_PERSONALITY:            makeSyscallSignature("personality", ParamTypeAny),
+	SYS_USTAT:                  makeSyscallSignature("ustat", ParamTypeAny, ParamTypeAny),
+	SYS_STATFS:                 makeSyscallSignature("statfs", ParamTypePath, ParamTypeAny),
+	SYS_FSTATFS:                makeSyscallSignature("fstatfs", ParamTypeFd, ParamTypeAny),
+	SYS_SYSFS:                  makeSyscallSignature("sysfs", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_GETPRIORITY:            makeSyscallSignature("getpriority", ParamTypeAny, ParamTypeAny),
+	SYS_SETPRIORITY:            makeSyscallSignature("setpriority", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SCHED_SETPARAM:         makeSyscallSignature("sched_setparam", ParamTypeAny, ParamTypeAny),
+	SYS_SCHED_GETPARAM:         makeSyscallSignature("sched_getparam", ParamTypeAny, ParamTypeAny),
+	SYS_SCHED_SETSCHEDULER:     makeSyscallSignature("sched_setscheduler", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SCHED_GETSCHEDULER:     makeSyscallSignature("sched_getscheduler", ParamTypeAny),
+	SYS_SCHED_GET_PRIORITY_MAX: makeSyscallSignature("sched_get_priority_max", ParamTypeAny),
+	SYS_SCHED_GET_PRIORITY_MIN: makeSyscallSignature("sched_get_priority_min", ParamTypeAny),
+	SYS_SCHED_RR_GET_INTERVAL:  makeSyscallSignature("sched_rr_get_interval", ParamTypeAny, ParamTypeAny),
+	SYS_MLOCK:                  makeSyscallSignature("mlock", ParamTypeAddr, ParamTypeSize),
+	SYS_MUNLOCK:                makeSyscallSignature("munlock", ParamTypeAddr, ParamTypeSize),
+	SYS_MLOCKALL:               makeSyscallSignature("mlockall", ParamTypeAny),
+	SYS_MUNLOCKALL:             makeSyscallSignature("munlockall"),
+	SYS_VHANGUP:                makeSyscallSignature("vhangup"),
+	SYS_MODIFY_LDT:             makeSyscallSignature("modify_ldt", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_PIVOT_ROOT:             makeSyscallSignature("pivot_root", ParamTypeAny, ParamTypeAny),
+	SYS__SYSCTL:                makeSyscallSignature("_sysctl", ParamTypeAny),
+	SYS_PRCTL:                  makeSyscallSignature("prctl", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_ARCH_PRCTL:             makeSyscallSignature("arch_prctl", ParamTypeAny, ParamTypeAny),
+	SYS_ADJTIMEX:               makeSyscallSignature("adjtimex", ParamTypeAny),
+	SYS_SETRLIMIT:              makeSyscallSignature("setrlimit", ParamTypeFlagRlimitResource, ParamTypeAny),
+	SYS_CHROOT:                 makeSyscallSignature("chroot", ParamTypePath),
+	SYS_SYNC:                   makeSyscallSignature("sync"),
+	SYS_ACCT:                   makeSyscallSignature("acct", ParamTypeAny),
+	SYS_SETTIMEOFDAY:           makeSyscallSignature("settimeofday", ParamTypeAny, ParamTypeAny),
+	SYS_MOUNT:                  makeSyscallSignature("mount", ParamTypePath, ParamTypePath, ParamTypeString, ParamTypeLong, ParamTypeAny),
+	SYS_UMOUNT2:                makeSyscallSignature("umount2", ParamTypePath, ParamTypeAny),
+	SYS_SWAPON:                 makeSyscallSignature("swapon", ParamTypeAny, ParamTypeAny),
+	SYS_SWAPOFF:                makeSyscallSignature("swapoff", ParamTypeAny),
+	SYS_REBOOT:                 makeSyscallSignature("reboot", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SETHOSTNAME:            makeSyscallSignature("sethostname", ParamTypeAny, ParamTypeAny),
+	SYS_SETDOMAINNAME:          makeSyscallSignature("setdomainname", ParamTypeAny, ParamTypeAny),
+	SYS_IOPL:                   makeSyscallSignature("iopl", ParamTypeAny),
+	SYS_IOPERM:                 makeSyscallSignature("ioperm", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_CREATE_MODULE:          makeSyscallSignature("create_module", ParamTypePath, ParamTypeAny),
+	SYS_INIT_MODULE:            makeSyscallSignature("init_module", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_DELETE_MODULE:          makeSyscallSignature("delete_module", ParamTypeAny, ParamTypeAny),
+	SYS_GET_KERNEL_SYMS:        makeSyscallSignature("get_kernel_syms", ParamTypeAny),
+	// SYS_QUERY_MODULE:query_module (only present in Linux < 2.6)
+	SYS_QUOTACTL:   makeSyscallSignature("quotactl", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_NFSSERVCTL: makeSyscallSignature("nfsservctl", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	// SYS_GETPMSG:getpmsg (not implemented in the Linux kernel)
+	// SYS_PUTPMSG:putpmsg (not implemented in the Linux kernel)
+	// SYSCALL:afs_syscall (not implemented in the Linux kernel)
+	// SYS_TUXCALL:tuxcall (not implemented in the Linux kernel)
+	// SYS_SECURITY:security (not implemented in the Linux kernel)
+	SYS_GETTID:            makeSyscallSignature("gettid"),
+	SYS_READAHEAD:         makeSyscallSignature("readahead", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SETXATTR:          makeSyscallSignature("setxattr", ParamTypePath, ParamTypeString, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_LSETXATTR:         makeSyscallSignature("lsetxattr", ParamTypePath, ParamTypeString, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_FSETXATTR:         makeSyscallSignature("fsetxattr", ParamTypeFd, ParamTypeString, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_GETXATTR:          makeSyscallSignature("getxattr", ParamTypePath, ParamTypeString, ParamTypeAny, ParamTypeAny),
+	SYS_LGETXATTR:         makeSyscallSignature("lgetxattr", ParamTypePath, ParamTypeString, ParamTypeAny, ParamTypeAny),
+	SYS_FGETXATTR:         makeSyscallSignature("fgetxattr", ParamTypeFd, ParamTypeString, ParamTypeAny, ParamTypeAny),
+	SYS_LISTXATTR:         makeSyscallSignature("listxattr", ParamTypePath, ParamTypeAny, ParamTypeAny),
+	SYS_LLISTXATTR:        makeSyscallSignature("llistxattr", ParamTypePath, ParamTypeAny, ParamTypeAny),
+	SYS_FLISTXATTR:        makeSyscallSignature("flistxattr", ParamTypeFd, ParamTypeAny, ParamTypeAny),
+	SYS_REMOVEXATTR:       makeSyscallSignature("removexattr", ParamTypePath, ParamTypeString),
+	SYS_LREMOVEXATTR:      makeSyscallSignature("lremovexattr", ParamTypePath, ParamTypeString),
+	SYS_FREMOVEXATTR:      makeSyscallSignature("fremovexattr", ParamTypeFd, ParamTypeString),
+	SYS_TKILL:             makeSyscallSignature("tkill", ParamTypeInt, ParamTypeSignal),
+	SYS_TIME:              makeSyscallSignature("time", ParamTypeAny),
+	SYS_FUTEX:             makeSyscallSignature("futex", ParamTypeAddr, ParamTypeFlagFutexOp, ParamTypeUint, ParamTypeTimespec, ParamTypeAddr, ParamTypeUint),
+	SYS_SCHED_SETAFFINITY: makeSyscallSignature("sched_setaffinity", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SCHED_GETAFFINITY: makeSyscallSignature("sched_getaffinity", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SET_THREAD_AREA:   makeSyscallSignature("set_thread_area", ParamTypeAny),
+	SYS_IO_SETUP:          makeSyscallSignature("io_setup", ParamTypeAny, ParamTypeAny),
+	SYS_IO_DESTROY:        makeSyscallSignature("io_destroy", ParamTypeAny),
+	SYS_IO_GETEVENTS:      makeSyscallSignature("io_getevents", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_IO_SUBMIT:         makeSyscallSignature("io_submit", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_IO_CANCEL:         makeSyscallSignature("io_cancel", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_GET_THREAD_AREA:   makeSyscallSignature("get_thread_area", ParamTypeAny),
+	SYS_LOOKUP_DCOOKIE:    makeSyscallSignature("lookup_dcookie", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_EPOLL_CREATE:      makeSyscallSignature("epoll_create", ParamTypeInt),
+	// SYS_EPOLL_CTL_OLD:epoll_ctl_old (not implemented in the Linux kernel)
+	// SYS_EPOLL_WAIT_OLD:epoll_wait_old (not implemented in the Linux kernel)
+	SYS_REMAP_FILE_PAGES: makeSyscallSignature("remap_file_pages", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_GETDENTS64:       makeSyscallSignature("getdents64", ParamTypeFd, ParamTypeAny, ParamTypeAny),
+	SYS_SET_TID_ADDRESS:  makeSyscallSignature("set_tid_address", ParamTypeAddr),
+	SYS_RESTART_SYSCALL:  makeSyscallSignature("restart_syscall"),
+	SYS_SEMTIMEDOP:       makeSyscallSignature("semtimedop", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_FADVISE64:        makeSyscallSignature("fadvise64", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_TIMER_CREATE:     makeSyscallSignature("timer_create", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_TIMER_SETTIME:    makeSyscallSignature("timer_settime", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_TIMER_GETTIME:    makeSyscallSignature("timer_gettime", ParamTypeAny, ParamTypeAny),
+	SYS_TIMER_GETOVERRUN: makeSyscallSignature("timer_getoverrun", ParamTypeAny),
+	SYS_TIMER_DELETE:     makeSyscallSignature("timer_delete", ParamTypeAny),
+	SYS_CLOCK_SETTIME:    makeSyscallSignature("clock_settime", ParamTypeAny, ParamTypeAny),
+	SYS_CLOCK_GETTIME:    makeSyscallSignature("clock_gettime", ParamTypeInt, ParamTypeTimespec),
+	SYS_CLOCK_GETRES:     makeSyscallSignature("clock_getres", ParamTypeInt, ParamTypeTimespec),
+	SYS_CLOCK_NANOSLEEP:  makeSyscallSignature("clock_nanosleep", ParamTypeInt, ParamTypeInt, ParamTypeTimespec, ParamTypeTimespec),
+	SYS_EXIT_GROUP:       makeSyscallSignature("exit_group", ParamTypeInt),
+	SYS_EPOLL_WAIT:       makeSyscallSignature("epoll_wait", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_EPOLL_CTL:        makeSyscallSignature("epoll_ctl", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_TGKILL:           makeSyscallSignature("tgkill", ParamTypeInt, ParamTypeInt, ParamTypeSignal),
+	SYS_UTIMES:           makeSyscallSignature("utimes", ParamTypePath, ParamTypeAny),
+	// SYS_VSERVER:vserver (not implemented in the Linux kernel)
+	SYS_MBIND:             makeSyscallSignature("mbind", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SET_MEMPOLICY:     makeSyscallSignature("set_mempolicy", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_GET_MEMPOLICY:     makeSyscallSignature("get_mempolicy", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_MQ_OPEN:           makeSyscallSignature("mq_open", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_MQ_UNLINK:         makeSyscallSignature("mq_unlink", ParamTypeAny),
+	SYS_MQ_TIMEDSEND:      makeSyscallSignature("mq_timedsend", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_MQ_TIMEDRECEIVE:   makeSyscallSignature("mq_timedreceive", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_MQ_NOTIFY:         makeSyscallSignature("mq_notify", ParamTypeAny, ParamTypeAny),
+	SYS_MQ_GETSETATTR:     makeSyscallSignature("mq_getsetattr", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_KEXEC_LOAD:        makeSyscallSignature("kexec_load", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_WAITID:            makeSyscallSignature("waitid", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_ADD_KEY:           makeSyscallSignature("add_key", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_REQUEST_KEY:       makeSyscallSignature("request_key", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_KEYCTL:            makeSyscallSignature("keyctl", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_IOPRIO_SET:        makeSyscallSignature("ioprio_set", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_IOPRIO_GET:        makeSyscallSignature("ioprio_get", ParamTypeAny, ParamTypeAny),
+	SYS_INOTIFY_INIT:      makeSyscallSignature("inotify_init"),
+	SYS_INOTIFY_ADD_WATCH: makeSyscallSignature("inotify_add_watch", ParamTypeFd, ParamTypePath, ParamTypeAny),
+	SYS_INOTIFY_RM_WATCH:  makeSyscallSignature("inotify_rm_watch", ParamTypeAny, ParamTypeAny),
+	SYS_MIGRATE_PAGES:     makeSyscallSignature("migrate_pages", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_OPENAT:            makeSyscallSignature("openat", ParamTypeFd, ParamTypePath, ParamTypeFlagOpen, ParamTypeAny),
+	SYS_MKDIRAT:           makeSyscallSignature("mkdirat", ParamTypeFd, ParamTypePath, ParamTypeMode),
+	SYS_MKNODAT:           makeSyscallSignature("mknodat", ParamTypeFd, ParamTypePath, ParamTypeMode, ParamTypeUint),
+	SYS_FCHOWNAT:          makeSyscallSignature("fchownat", ParamTypeFd, ParamTypePath, ParamTypeUint, ParamTypeUint, ParamTypeFlagAt),
+	SYS_FUTIMESAT:         makeSyscallSignature("futimesat", ParamTypeFd, ParamTypePath, ParamTypeAny),
+	SYS_NEWFSTATAT:        makeSyscallSignature("newfstatat", ParamTypeFd, ParamTypePath, ParamTypeStat, ParamTypeFlagAt),
+	SYS_UNLINKAT:          makeSyscallSignature("unlinkat", ParamTypeFd, ParamTypePath, ParamTypeFlagAt),
+	SYS_RENAMEAT:          makeSyscallSignature("renameat", ParamTypeFd, ParamTypePath, ParamTypeFd, ParamTypePath),
+	SYS_LINKAT:            makeSyscallSignature("linkat", ParamTypeFd, ParamTypePath, ParamTypeFd, ParamTypePath, ParamTypeFlagAt),
+	SYS_SYMLINKAT:         makeSyscallSignature("symlinkat", ParamTypePath, ParamTypeFd, ParamTypePath),
+	SYS_READLINKAT:        makeSyscallSignature("readlinkat", ParamTypeFd, ParamTypePath, ParamTypeAddr, ParamTypeSize),
+	SYS_FCHMODAT:          makeSyscallSignature("fchmodat", ParamTypeFd, ParamTypePath, ParamTypeMode),
+	SYS_FACCESSAT:         makeSyscallSignature("faccessat", ParamTypeFd, ParamTypePath, ParamTypeFlagAccessMode, ParamTypeFlagAt),
+	SYS_PSELECT6:          makeSyscallSignature("pselect6", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_PPOLL:             makeSyscallSignature("ppoll", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_UNSHARE:           makeSyscallSignature("unshare", ParamTypeFlagClone),
+	SYS_SET_ROBUST_LIST:   makeSyscallSignature("set_robust_list", ParamTypeAny, ParamTypeAny),
+	SYS_GET_ROBUST_LIST:   makeSyscallSignature("get_robust_list", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SPLICE:            makeSyscallSignature("splice", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_TEE:               makeSyscallSignature("tee", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SYNC_FILE_RANGE:   makeSyscallSignature("sync_file_range", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_VMSPLICE:          makeSyscallSignature("vmsplice", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_MOVE_PAGES:        makeSyscallSignature("move_pages", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_UTIMENSAT:         makeSyscallSignature("utimensat", ParamTypeFd, ParamTypePath, ParamTypeAny, ParamTypeFlagAt),
+	SYS_EPOLL_PWAIT:       makeSyscallSignature("epoll_pwait", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SIGNALFD:          makeSyscallSignature("signalfd", ParamTypeFd, ParamTypeAny, ParamTypeSize),
+	SYS_TIMERFD_CREATE:    makeSyscallSignature("timerfd_create", ParamTypeInt, ParamTypeInt),
+	SYS_EVENTFD:           makeSyscallSignature("eventfd", ParamTypeUint),
+	SYS_FALLOCATE:         makeSyscallSignature("fallocate", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_TIMERFD_SETTIME:   makeSyscallSignature("timerfd_settime", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_TIMERFD_GETTIME:   makeSyscallSignature("timerfd_gettime", ParamTypeAny, ParamTypeAny),
+	SYS_ACCEPT4:           makeSyscallSignature("accept4", ParamTypeFd, ParamTypeAny, ParamTypeAny, ParamTypeInt),
+	SYS_SIGNALFD4:         makeSyscallSignature("signalfd4", ParamTypeFd, ParamTypeAny, ParamTypeSize, ParamTypeInt),
+	SYS_EVENTFD2:          makeSyscallSignature("eventfd2", ParamTypeUint, ParamTypeInt),
+	SYS_EPOLL_CREATE1:     makeSyscallSignature("epoll_create1", ParamTypeInt),
+	SYS_DUP3:              makeSyscallSignature("dup3", ParamTypeFd, ParamTypeFd, ParamTypeInt),
+	SYS_PIPE2:             makeSyscallSignature("pipe2", ParamTypePipeFd, ParamTypeInt),
+	SYS_INOTIFY_INIT1:     makeSyscallSignature("inotify_init1", ParamTypeInt),
+	SYS_PREADV:            makeSyscallSignature("preadv", ParamTypeFd, ParamTypeIovec, ParamTypeLong, ParamTypeLong),
+	SYS_PWRITEV:           makeSyscallSignature("pwritev", ParamTypeFd, ParamTypeIovec, ParamTypeLong, ParamTypeLong),
+	SYS_RT_TGSIGQUEUEINFO: makeSyscallSignature("rt_tgsigqueueinfo", ParamTypeInt, ParamTypeInt, ParamTypeSignal, ParamTypeAny),
+	SYS_PERF_EVENT_OPEN:   makeSyscallSignature("perf_event_open", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_RECVMMSG:          makeSyscallSignature("recvmmsg", ParamTypeFd, ParamTypeAddr, ParamTypeUint, ParamTypeUint, ParamTypeTimespec),
+	SYS_FANOTIFY_INIT:     makeSyscallSignature("fanotify_init", ParamTypeUint, ParamTypeUint),
+	SYS_FANOTIFY_MARK:     makeSyscallSignature("fanotify_mark", ParamTypeFd, ParamTypeAny, ParamTypeAny, ParamTypeFd, ParamTypePath),
+	SYS_PRLIMIT64:         makeSyscallSignature("prlimit64", ParamTypeInt, ParamTypeFlagRlimitResource, ParamTypeAny, ParamTypeAny),
+	SYS_NAME_TO_HANDLE_AT: makeSyscallSignature("name_to_handle_at", ParamTypeFd, ParamTypePath, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_OPEN_BY_HANDLE_AT: makeSyscallSignature("open_by_handle_at", ParamTypeFd, ParamTypeAny, ParamTypeFlagOpen),
+	SYS_CLOCK_ADJTIME:     makeSyscallSignature("clock_adjtime", ParamTypeAny, ParamTypeAny),
+	SYS_SYNCFS:            makeSyscallSignature("syncfs", ParamTypeAny),
+	SYS_SENDMMSG:          makeSyscallSignature("sendmmsg", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SETNS:             makeSyscallSignature("setns", ParamTypeAny, ParamTypeAny),
+	SYS_GETCPU:            makeSyscallSignature("getcpu", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_PROCESS_VM_READV:  makeSyscallSignature("process_vm_readv", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_PROCESS_VM_WRITEV: makeSyscallSignature("process_vm_writev", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_KCMP:              makeSyscallSignature("kcmp", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_FINIT_MODULE:      makeSyscallSignature("finit_module", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SCHED_SETATTR:     makeSyscallSignature("sched_setattr", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_SCHED_GETATTR:     makeSyscallSignature("sched_getattr", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_RENAMEAT2:         makeSyscallSignature("renameat2", ParamTypeFd, ParamTypePath, ParamTypeFd, ParamTypePath, ParamTypeAny),
+	SYS_SECCOMP:           makeSyscallSignature("seccomp", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_GETRANDOM:         makeSyscallSignature("getrandom", ParamTypeAddr, ParamTypeSize, ParamTypeUint),
+	SYS_MEMFD_CREATE:      makeSyscallSignature("memfd_create", ParamTypeString, ParamTypeUint),
+	SYS_KEXEC_FILE_LOAD:   makeSyscallSignature("kexec_file_load", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_BPF:               makeSyscallSignature("bpf", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_EXECVEAT:          makeSyscallSignature("execveat", ParamTypeFd, ParamTypePath, ParamTypeArgv, ParamTypeEnvp, ParamTypeInt),
+	SYS_USERFAULTFD:       makeSyscallSignature("userfaultfd", ParamTypeInt),
+	SYS_MEMBARRIER:        makeSyscallSignature("membarrier", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_MLOCK2:            makeSyscallSignature("mlock2", ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_COPY_FILE_RANGE:   makeSyscallSignature("copy_file_range", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_PREADV2:           makeSyscallSignature("preadv2", ParamTypeFd, ParamTypeIovec, ParamTypeLong, ParamTypeLong, ParamTypeLong, ParamTypeInt),
+	SYS_PWRITEV2:          makeSyscallSignature("pwritev2", ParamTypeFd, ParamTypeIovec, ParamTypeLong, ParamTypeLong, ParamTypeLong, ParamTypeInt),
+	SYS_PKEY_MPROTECT:     makeSyscallSignature("pkey_mprotect", ParamTypeAddr, ParamTypeSize, ParamTypeFlagMmapProt, ParamTypeInt),
+	SYS_PKEY_ALLOC:        makeSyscallSignature("pkey_alloc", ParamTypeAny, ParamTypeAny),
+	SYS_PKEY_FREE:         makeSyscallSignature("pkey_free", ParamTypeAny),
+	SYS_STATX:             makeSyscallSignature("statx", ParamTypeFd, ParamTypePath, ParamTypeFlagAt, ParamTypeUint, ParamTypeAny),
+	SYS_IO_URING_SETUP:    makeSyscallSignature("io_uring_setup", ParamTypeAny, ParamTypeAny),
+	SYS_IO_URING_ENTER:    makeSyscallSignature("io_uring_setup", ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny, ParamTypeAny),
+	SYS_PIDFD_OPEN:        makeSyscallSignature("pidfd_open", ParamTypeInt, ParamTypeUint),
+	SYS_CLONE3:            makeSyscallSignature("clone3", ParamTypeCloneArgs, ParamTypeSize),
+	SYS_CLOSE_RANGE:       makeSyscallSignature("close_range", ParamTypeUint, ParamTypeUint, ParamTypeUint),
+	SYS_OPENAT2:           makeSyscallSignature("openat2", ParamTypeFd, ParamTypePath, ParamTypeOpenHow, ParamTypeSize),
+	SYS_PIDFD_GETFD:       makeSyscallSignature("pidfd_getfd", ParamTypeFd, ParamTypeInt, ParamTypeUint),
+	SYS_FACCESSAT2:        makeSyscallSignature("faccessat2", ParamTypeFd, ParamTypePath, ParamTypeFlagAccessMode, ParamTypeFlagAt),
+}
@@ -0,0 +1,56 @@
+package ptrace
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestParseCompatScmRights(t *testing.T) {
+	var buf []byte
+	var cmsg = func(level int, typ int, data ...uint32) {
+		var b = make([]byte, sizeofCompatCmsghdr+4*len(data))
+		nativeEndian.PutUint32(b[0:], uint32(len(b)))
+		nativeEndian.PutUint32(b[4:], uint32(level))
+		nativeEndian.PutUint32(b[8:], uint32(typ))
+		for i, v := range data {
+			nativeEndian.PutUint32(b[sizeofCompatCmsghdr+4*i:], v)
+		}
+		buf = append(buf, b...)
+	}
+	cmsg(unix.SOL_SOCKET, unix.SCM_CREDENTIALS, 1, 0, 0) // pid, uid, gid
+	cmsg(unix.SOL_SOCKET, unix.SCM_RIGHTS, 3, 4)
+	cmsg(unix.SOL_IP, unix.SCM_RIGHTS, 5)
+	cmsg(unix.SOL_SOCKET, unix.SCM_RIGHTS, 6)
+	buf = append(buf, 0xff, 0xff, 0xff, 0xff) // garbage
+
+	if fds := parseCompatScmRights(buf); !reflect.DeepEqual(fds, []int{3, 4, 6}) {
+		t.Fatalf("fds: %v, want [3 4 6]", fds)
+	}
+	if fds := parseCompatScmRights(buf[:sizeofCompatCmsghdr+4]); fds != nil { // truncated
+		t.Fatalf("fds: %v, want none", fds)
+	}
+}
+
+func TestCompatRetval(t *testing.T) {
+	var tests = []struct {
+		v     uint32
+		want  int
+		error bool
+	}{
+		{0, 0, false},
+		{3, 3, false},
+		{0xfffffff2, -14, true}, // -EFAULT
+		{0xfffff001, -4095, true},
+		{0xfffff000, 0xfffff000, false},
+		{0x80000000, 0x80000000, false}, // e.g. mmap2 returns an address above 2 GiB
+		{0xb7f00000, 0xb7f00000, false},
+	}
+	for _, tt := range tests {
+		var r = SyscallRetval{value: compatRetval(tt.v)}
+		if r.value != tt.want || r.HasError() != tt.error {
+			t.Errorf("compatRetval(%#x) = %#x, error: %v", tt.v, r.value, r.HasError())
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// A child stops itself, it must stay stopped until the parent sees it and sends a SIGCONT.
+#include <fcntl.h>
+#include <signal.h>
+#include <sys/wait.h>
+#include <unistd.h>
+
+int main(void) {
+  int fds[2];
+  if (pipe(fds) != 0) return 1;
+
+  pid_t pid = fork();
+  if (pid < 0) return 1;
+  if (pid == 0) {
+    char c;
+    raise(SIGSTOP);
+    fcntl(fds[0], F_SETFL, O_NONBLOCK);
+    _exit(read(fds[0], &c, 1) == 1 ? 0 : 1); // resumed before the SIGCONT
+  }
+
+  int status;
+  if (waitpid(pid, &status, WUNTRACED) != pid || !WIFSTOPPED(status)) return 2;
+  if (write(fds[1], "x", 1) != 1) return 3;
+  kill(pid, SIGCONT);
+  if (waitpid(pid, &status, 0) != pid || !WIFEXITED(status)) return 4;
+  return WEXITSTATUS(status) == 0 ? 0 : 5;
+}
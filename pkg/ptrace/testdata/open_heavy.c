@@ -0,0 +1,22 @@
+// Open and close the files in a loop, e.g. a compiler searching the include paths.
+#include <fcntl.h>
+#include <stdlib.h>
+#include <unistd.h>
+
+static const char *paths[] = {
+  "/etc/hostname",
+  "/usr/include/stdio.h",
+  "/usr/lib/x86_64-linux-gnu/gsandbox/not/found/in/the/search/path/of/the/compiler.h",
+  "/proc/self/status",
+};
+
+int main(int argc, char *argv[]) {
+  int n = argc > 1 ? atoi(argv[1]) : 1000;
+  for (int i = 0; i < n; i++) {
+    int fd = open(paths[i % (sizeof(paths) / sizeof(paths[0]))], O_RDONLY);
+    if (fd >= 0) {
+      close(fd);
+    }
+  }
+  return 0;
+}
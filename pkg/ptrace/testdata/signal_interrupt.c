@@ -0,0 +1,56 @@
+// Syscalls interrupted by signals, each step exits with a distinct code on failure.
+#define _DEFAULT_SOURCE
+#include <errno.h>
+#include <signal.h>
+#include <string.h>
+#include <time.h>
+#include <unistd.h>
+
+static int fds[2];
+
+static void on_alarm(int sig) {
+  (void)sig;
+  (void)!write(fds[1], "x", 1);
+}
+
+static void set_handler(void (*handler)(int), int flags) {
+  struct sigaction sa;
+  memset(&sa, 0, sizeof(sa));
+  sa.sa_handler = handler;
+  sa.sa_flags = flags;
+  sigaction(SIGALRM, &sa, NULL);
+}
+
+int main(void) {
+  char c;
+
+  if (pipe(fds) != 0) {
+    return 10;
+  }
+
+  // read(2) fails with EINTR, without SA_RESTART
+  set_handler(on_alarm, 0);
+  ualarm(50000, 0);
+  if (read(fds[0], &c, 1) != -1 || errno != EINTR) {
+    return 1;
+  }
+  if (read(fds[0], &c, 1) != 1) {
+    return 2;
+  }
+
+  // read(2) is restarted, with SA_RESTART
+  set_handler(on_alarm, SA_RESTART);
+  ualarm(50000, 0);
+  if (read(fds[0], &c, 1) != 1) {
+    return 3;
+  }
+
+  // nanosleep(2) is restarted by restart_syscall(2), the ignored signal still stops a tracee
+  set_handler(SIG_IGN, 0);
+  ualarm(50000, 0);
+  struct timespec ts = {0, 200 * 1000 * 1000};
+  if (nanosleep(&ts, NULL) != 0) {
+    return 4;
+  }
+  return 0;
+}
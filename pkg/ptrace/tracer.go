@@ -2,13 +2,16 @@ package ptrace
 
 import (
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"syscall"
 
 	"golang.org/x/sys/unix"
 )
 
 func Trace(pid int, handler TracerHandler) {
-	var tracer = Tracer{pid: pid, tracees: make(map[int]*Tracee)}
+	var tracer = Tracer{pid: pid, tracees: make(map[int]*Tracee), pendings: make(map[int]struct{}), killed: make(map[int]syscall.Signal)}
 	tracer.trace(handler)
 }
 
@@ -17,14 +20,21 @@ type TracerHandler interface {
 	HandleTracerPanicEvent(err error)                                                     // panic
 	HandleTracerExitedEvent(pid int, ws syscall.WaitStatus, rusage syscall.Rusage)        // ws.Exited()
 	HandleTracerSignaledEvent(pid int, ws syscall.WaitStatus, rusage syscall.Rusage)      // ws.Signaled()
-	HandleTracerNewChildEvent(pid int, childPid int)                                      // PTRACE_EVENT_CLONE
+	HandleTracerNewChildEvent(pid int, childPid int, cloneFlags uint64)                   // PTRACE_EVENT_CLONE
+	HandleTracerExecEvent(pid int, formerPid int)                                         // PTRACE_EVENT_EXEC
 	HandleTracerSyscallEnterEvent(pid int, curr *Syscall) (continued bool)                // when syscall enter
 	HandleTracerSyscallLeaveEvent(pid int, curr *Syscall, prev *Syscall) (continued bool) // when syscall leave
 }
 
+// No PTRACE_EVENT_*, e.g. the SIGTRAP after execve(2) of PTRACE_TRACEME
+const ptraceEventNone = 0
+
 type Tracer struct {
-	pid     int
-	tracees map[int]*Tracee
+	pid      int
+	tracees  map[int]*Tracee
+	pendings map[int]struct{}       // new children which stopped before their parent reports them
+	killed   map[int]syscall.Signal // the init of a PID namespace killed on behalf of a signal, plz see Tracer#isUnkillable
+	exited   bool                   // the root tracee has exited
 }
 
 func (t *Tracer) trace(handler TracerHandler) {
@@ -34,19 +44,33 @@ func (t *Tracer) trace(handler TracerHandler) {
 	flag = flag | syscall.PTRACE_O_TRACEFORK    // automatically trace fork(2) children
 	flag = flag | syscall.PTRACE_O_TRACEVFORK   // automatically trace vfork(2) children
 	flag = flag | syscall.PTRACE_O_TRACEEXIT    // stop the tracee at exit
-	if err := syscall.PtraceSetOptions(t.pid, flag); err != nil {
-		err := fmt.Errorf("PtraceSetOptions: %s", err)
+	flag = flag | syscall.PTRACE_O_TRACEEXEC    // stop the tracee at the next execve(2), instead of a SIGTRAP
+	flag = flag | unix.PTRACE_O_EXITKILL        // send a SIGKILL to every tracee if the tracer exits
+
+	var rusage syscall.Rusage
+	var currTracee *Tracee
+	var curr *Syscall
+	var ws syscall.WaitStatus
+	if err := t.seize(flag); err != nil {
+		handler.HandleTracerLogging(t.pid, err.Error())
+		handler.HandleTracerPanicEvent(err)
+		return
+	}
+	t.addTracee(t.pid)
+	if err := syscall.PtraceSyscall(t.pid, 0); err != nil {
+		err := fmt.Errorf("PtraceSyscall: %s", err)
 		handler.HandleTracerLogging(t.pid, err.Error())
 		handler.HandleTracerPanicEvent(err)
 		return
 	}
 
-	var ws syscall.WaitStatus
-	var rusage syscall.Rusage
-	var currTracee *Tracee
-	var curr *Syscall
 	for {
-		wpid, err := syscall.Wait4(-t.pid, &ws, syscall.WALL, &rusage)
+		var deliverSignal syscall.Signal // the signal injected when the tracee resumes
+		// wait for the tracees of the calling thread, a tracee may leave the process group, e.g. job control
+		wpid, err := syscall.Wait4(-1, &ws, syscall.WALL|unix.WNOTHREAD, &rusage)
+		if err == syscall.ECHILD && t.exited { // every tracee is gone, e.g. reaped by a sandbox cleanup
+			return
+		}
 		if err != nil {
 			err := fmt.Errorf("Wait: %s", err)
 			handler.HandleTracerLogging(t.pid, err.Error())
@@ -59,7 +83,7 @@ func (t *Tracer) trace(handler TracerHandler) {
 			msg := fmt.Sprintf("tracee %d exited with return code %d", wpid, ws.ExitStatus())
 			handler.HandleTracerLogging(wpid, msg)
 			handler.HandleTracerExitedEvent(wpid, ws, rusage)
-			if wpid == t.pid {
+			if t.removeTracee(wpid) {
 				return
 			} else {
 				continue
@@ -68,10 +92,14 @@ func (t *Tracer) trace(handler TracerHandler) {
 
 		// check wait status - WIFSIGNALED
 		if ws.Signaled() {
+			if signal, ok := t.killed[wpid]; ok && ws.Signal() == syscall.SIGKILL {
+				ws = syscall.WaitStatus(signal) // as if it is killed by the signal
+				delete(t.killed, wpid)
+			}
 			msg := fmt.Sprintf("tracee %d terminated with signal %d(%s)", wpid, ws.Signal(), ws.Signal())
 			handler.HandleTracerLogging(wpid, msg)
 			handler.HandleTracerSignaledEvent(wpid, ws, rusage)
-			if wpid == t.pid {
+			if t.removeTracee(wpid) {
 				return
 			} else {
 				continue
@@ -86,6 +114,15 @@ func (t *Tracer) trace(handler TracerHandler) {
 			return
 		}
 
+		// A new child may stop before its parent reports PTRACE_EVENT_CLONE, hold it until the
+		// parent does, otherwise its syscalls are inspected before the handler knows it.
+		if _, ok := t.tracees[wpid]; !ok {
+			msg := fmt.Sprintf("tracee %d stopped before its parent reports it", wpid)
+			handler.HandleTracerLogging(wpid, msg)
+			t.pendings[wpid] = struct{}{}
+			continue
+		}
+
 		// check wait status - WIFSTOPPED
 		switch signal := ws.StopSignal(); signal {
 		// syscall-stops
@@ -101,29 +138,47 @@ func (t *Tracer) trace(handler TracerHandler) {
 			}
 
 			// reterive syscall info
-			curr, err = GetSyscall(wpid)
+			curr, err = getSyscall(wpid, currTracee.in)
 			if err != nil {
 				handler.HandleTracerLogging(wpid, err.Error())
 				handler.HandleTracerPanicEvent(err)
 				return
 			}
 
-			// handle syscall event
-			if currTracee.insyscall { // syscall enter event
-				// special case
-				switch curr.GetNR() {
-				case unix.SYS_EXECVE, // an additional notification event of `exec` in child?
-					unix.SYS_CLONE: // an additional notification event of `clone` in child?
-					if err := curr.ReadRetval(); err != nil {
-						handler.HandleTracerLogging(wpid, err.Error())
-						handler.HandleTracerPanicEvent(err)
-						return
-					}
-					if curr.GetRetval().GetValue() == 0 {
-						goto TRACE_CONTINUE
+			// identify syscall-enter-stop or syscall-exit-stop
+			var entering bool
+			switch curr.op {
+			case unix.PTRACE_SYSCALL_INFO_ENTRY, unix.PTRACE_SYSCALL_INFO_SECCOMP:
+				entering = true
+			case unix.PTRACE_SYSCALL_INFO_EXIT:
+				entering = false
+				if currTracee.in == nil { // e.g. `clone` returns in a new child, `execve` returns in a new program
+					msg := fmt.Sprintf("tracee %d leaves syscall %s without entering", wpid, curr.GetName())
+					handler.HandleTracerLogging(wpid, msg)
+					currTracee.insyscall = true
+					goto TRACE_CONTINUE
+				}
+			default: // PTRACE_GET_SYSCALL_INFO is not supported, fallback to the toggle
+				entering = currTracee.insyscall
+				if entering {
+					// special case
+					switch curr.GetNR() {
+					case SYS_EXECVE, // an additional notification event of `exec` in child?
+						SYS_CLONE, SYS_CLONE3, SYS_FORK, SYS_VFORK: // an additional notification event of `clone` in child?
+						if err := curr.ReadRetval(); err != nil {
+							handler.HandleTracerLogging(wpid, err.Error())
+							handler.HandleTracerPanicEvent(err)
+							return
+						}
+						if curr.GetRetval().GetValue() == 0 {
+							goto TRACE_CONTINUE
+						}
 					}
 				}
+			}
 
+			// handle syscall event
+			if entering { // syscall enter event
 				// inspect
 				if continued := handler.HandleTracerSyscallEnterEvent(wpid, curr); continued {
 					currTracee.insyscall = false
@@ -150,15 +205,40 @@ func (t *Tracer) trace(handler TracerHandler) {
 					handler.HandleTracerLogging(wpid, err.Error())
 					handler.HandleTracerPanicEvent(err)
 					return
+				} else if cloneFlags, err := t.getCloneFlags(wpid); err != nil {
+					handler.HandleTracerLogging(wpid, err.Error())
+					handler.HandleTracerPanicEvent(err)
+					return
 				} else {
-					msg := fmt.Sprintf("tracee %d creates a new child %d", wpid, childPid)
+					msg := fmt.Sprintf("tracee %d creates a new child %d with flags %s", wpid, childPid, FlagClone(cloneFlags))
 					handler.HandleTracerLogging(wpid, msg)
-					handler.HandleTracerNewChildEvent(wpid, int(childPid))
+					handler.HandleTracerNewChildEvent(wpid, int(childPid), cloneFlags)
+					if err := t.addChildTracee(int(childPid)); err != nil {
+						handler.HandleTracerLogging(int(childPid), err.Error())
+						handler.HandleTracerPanicEvent(err)
+						return
+					}
+					goto TRACE_CONTINUE
+				}
+			case syscall.PTRACE_EVENT_EXEC:
+				if formerPid, err := syscall.PtraceGetEventMsg(wpid); err != nil {
+					err := fmt.Errorf("PtraceGetEventMsg: %s", err)
+					handler.HandleTracerLogging(wpid, err.Error())
+					handler.HandleTracerPanicEvent(err)
+					return
+				} else {
+					if int(formerPid) != wpid { // a non-leader thread execs, it takes over the thread group leader's pid
+						msg := fmt.Sprintf("tracee %d execs in thread %d", wpid, formerPid)
+						handler.HandleTracerLogging(wpid, msg)
+						t.tracees[wpid] = t.tracees[int(formerPid)]
+						delete(t.tracees, int(formerPid))
+					}
+					handler.HandleTracerExecEvent(wpid, int(formerPid))
 					goto TRACE_CONTINUE
 				}
 			case syscall.PTRACE_EVENT_EXIT:
 				goto TRACE_CONTINUE
-			case syscall.PTRACE_TRACEME:
+			case unix.PTRACE_EVENT_STOP: // the initial stop of a new child, or the group-stop is ended by a SIGCONT
 				goto TRACE_CONTINUE
 			default:
 				msg := fmt.Sprintf("unexpected trap cause %d", tc)
@@ -166,28 +246,39 @@ func (t *Tracer) trace(handler TracerHandler) {
 				goto TRACE_CONTINUE
 			}
 
-		// syscall.PTRACE_EVENT_CLONE
-		// syscall.PTRACE_EVENT_FORK
-		// syscall.PTRACE_EVENT_VFORK
-		case syscall.SIGSTOP:
-			msg := fmt.Sprintf("tracee %d started with a SIGSTOP", wpid)
-			handler.HandleTracerLogging(wpid, msg)
+		// signal-delivery-stop or group-stop
+		default:
+			goto SIGNAL_DELIVERY_STOP
+		}
+		goto TRACE_CONTINUE
 
-		// .
-		case syscall.SIGCHLD:
-			msg := fmt.Sprintf("tracee %d receives child exited signal", wpid)
+	SIGNAL_DELIVERY_STOP:
+		if signal := ws.StopSignal(); ptraceEvent(ws) == unix.PTRACE_EVENT_STOP {
+			// Keep the tracee stopped until it is woken up by a SIGCONT, but still be notified
+			// of the other events.
+			msg := fmt.Sprintf("tracee %d enters group-stop by signal %d(%s)", wpid, signal, signal)
 			handler.HandleTracerLogging(wpid, msg)
-
-		// signal-delivery-stop
-		default:
-			msg := fmt.Sprintf("unexpected stop signal %d(%s)", signal, signal)
+			if err := ptraceListen(wpid); err == nil {
+				continue
+			}
+		} else if tgid, ok := t.isUnkillable(wpid, signal); ok {
+			// The init of a PID namespace ignores a signal of the default action, even a fatal one raised by the
+			// kernel, e.g. SIGSEGV, when it is traced. Kill it instead, the signal is reported when it is reaped.
+			msg := fmt.Sprintf("tracee %d receives signal %d(%s), killed as the init of a PID namespace", wpid, signal, signal)
+			handler.HandleTracerLogging(wpid, msg)
+			t.killed[tgid] = signal
+			_ = syscall.Kill(wpid, syscall.SIGKILL)
+			continue // woken up by the SIGKILL
+		} else {
+			msg := fmt.Sprintf("tracee %d receives signal %d(%s)", wpid, signal, signal)
 			handler.HandleTracerLogging(wpid, msg)
+			deliverSignal = signal
 		}
 
 	TRACE_CONTINUE:
 		// Resume tracee execution. Make the kernel stop the child process whenever a
 		// system call entry or exit is made.
-		if err := syscall.PtraceSyscall(wpid, 0); err != nil {
+		if err := syscall.PtraceSyscall(wpid, int(deliverSignal)); err != nil {
 			err := fmt.Errorf("PtraceSyscall: %s", err)
 			handler.HandleTracerLogging(wpid, err.Error())
 			handler.HandleTracerPanicEvent(err)
@@ -196,8 +287,134 @@ func (t *Tracer) trace(handler TracerHandler) {
 	}
 }
 
+// The command is started by the go runtime with PTRACE_TRACEME, but PTRACE_LISTEN only works on a tracee
+// attached by PTRACE_SEIZE. So detach it with a SIGSTOP at the SIGTRAP after execve(2), seize it in the
+// group-stop, then wake it up by a SIGCONT. It does not run any instruction of the command in between.
+func (t *Tracer) seize(options int) error {
+	var ws syscall.WaitStatus
+	for {
+		if _, err := syscall.Wait4(t.pid, &ws, syscall.WALL, nil); err != nil || !ws.Stopped() {
+			return fmt.Errorf("Wait: tracee %d is not stopped: %v", t.pid, err)
+		}
+		if ws.StopSignal() == syscall.SIGTRAP && ws.TrapCause() == ptraceEventNone {
+			break
+		}
+
+		// a signal received before execve(2), a stopping signal is dropped since a SIGCONT follows
+		var signal = ws.StopSignal()
+		switch signal {
+		case syscall.SIGSTOP, syscall.SIGTSTP, syscall.SIGTTIN, syscall.SIGTTOU:
+			signal = 0
+		}
+		if err := syscall.PtraceCont(t.pid, int(signal)); err != nil {
+			return fmt.Errorf("PtraceCont: %s", err)
+		}
+	}
+
+	if _, _, errno := syscall.Syscall6(syscall.SYS_PTRACE, syscall.PTRACE_DETACH, uintptr(t.pid), 0, uintptr(syscall.SIGSTOP), 0, 0); errno != 0 {
+		return fmt.Errorf("PtraceDetach: %s", errno)
+	}
+	if _, err := syscall.Wait4(t.pid, &ws, syscall.WALL|syscall.WUNTRACED, nil); err != nil || !ws.Stopped() {
+		return fmt.Errorf("Wait: tracee %d is not stopped: %v", t.pid, err)
+	}
+
+	if _, _, errno := syscall.Syscall6(syscall.SYS_PTRACE, unix.PTRACE_SEIZE, uintptr(t.pid), 0, uintptr(options), 0, 0); errno != 0 {
+		return fmt.Errorf("PtraceSeize: %s", errno)
+	}
+	if _, err := syscall.Wait4(t.pid, &ws, syscall.WALL, nil); err != nil || ptraceEvent(ws) != unix.PTRACE_EVENT_STOP {
+		return fmt.Errorf("Wait: tracee %d is not in group-stop: %v", t.pid, err)
+	}
+	if err := syscall.Kill(t.pid, syscall.SIGCONT); err != nil {
+		return fmt.Errorf("Kill: %s", err)
+	}
+	return nil
+}
+
 func (t *Tracer) addTracee(pid int) *Tracee {
 	var tracee = Tracee{insyscall: true}
 	t.tracees[pid] = &tracee
 	return &tracee
 }
+
+// The tracee is gone, returns true if it is the last one
+//
+// A multi-threaded root can exit before its threads, and descendants can outlive the root, so
+// keep tracing until every tracee is gone.
+func (t *Tracer) removeTracee(pid int) bool {
+	delete(t.tracees, pid)
+	delete(t.pendings, pid)
+	if pid == t.pid {
+		t.exited = true
+	}
+	return t.exited && len(t.tracees) == 0
+}
+
+// The tracee is stopped by a signal which the kernel ignores, returns the thread group id. It is the init of a
+// PID namespace, and the signal is fatal and of the default action, plz see signal(7).
+func (t *Tracer) isUnkillable(pid int, signal syscall.Signal) (int, bool) {
+	switch signal {
+	case syscall.SIGCHLD, syscall.SIGCONT, syscall.SIGURG, syscall.SIGWINCH, // ignored
+		syscall.SIGSTOP, syscall.SIGTSTP, syscall.SIGTTIN, syscall.SIGTTOU: // stopped
+		return 0, false
+	}
+
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return 0, false
+	}
+
+	var tgid, init, handled = 0, false, false
+	for _, line := range strings.Split(string(data), "\n") {
+		var fields = strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "Tgid:":
+			tgid, _ = strconv.Atoi(fields[1])
+		case "NStgid:": // the thread group id in each PID namespace, the last one is its own
+			init = fields[len(fields)-1] == "1"
+		case "SigIgn:", "SigCgt:": // ignored or caught
+			mask, _ := strconv.ParseUint(fields[1], 16, 64)
+			handled = handled || mask&(1<<(uint(signal)-1)) != 0
+		}
+	}
+	return tgid, init && !handled && tgid != 0
+}
+
+// The tracee is stopped in the syscall which created the child, read the flags from its arguments
+func (t *Tracer) getCloneFlags(pid int) (uint64, error) {
+	curr, err := GetSyscall(pid)
+	if err != nil {
+		return 0, err
+	}
+	return curr.ReadCloneFlags()
+}
+
+// The parent reports a new child, resume it if it is held
+func (t *Tracer) addChildTracee(pid int) error {
+	if _, ok := t.tracees[pid]; !ok {
+		t.addTracee(pid)
+	}
+	if _, ok := t.pendings[pid]; ok {
+		delete(t.pendings, pid) // the initial PTRACE_EVENT_STOP is held
+		if err := syscall.PtraceSyscall(pid, 0); err != nil {
+			return fmt.Errorf("PtraceSyscall: %s", err)
+		}
+	}
+	return nil
+}
+
+// The PTRACE_EVENT_* of a ptrace-stop, the signal-delivery-stop is ptraceEventNone
+func ptraceEvent(ws syscall.WaitStatus) int {
+	return int(ws >> 16)
+}
+
+// PTRACE_LISTEN, restart the stopped tracee, but prevent it from executing
+func ptraceListen(pid int) error {
+	_, _, errno := syscall.Syscall6(syscall.SYS_PTRACE, unix.PTRACE_LISTEN, uintptr(pid), 0, 0, 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package ptrace
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestTracerSignalInterruptedSyscalls(t *testing.T) {
+	testTracerSignalInterruptedSyscalls(t)
+}
+
+func TestTracerSignalInterruptedSyscallsWithoutSyscallInfo(t *testing.T) {
+	var former = atomic.LoadInt32(&syscallInfoUnsupported)
+	defer atomic.StoreInt32(&syscallInfoUnsupported, former)
+
+	atomic.StoreInt32(&syscallInfoUnsupported, 1) // Linux < 5.3, the ABI is read from the regs
+	testTracerSignalInterruptedSyscalls(t)
+}
+
+func testTracerSignalInterruptedSyscalls(t *testing.T) {
+	var prog = buildTestProg(t, "signal_interrupt")
+
+	var entered = make(map[int]*Syscall)
+	var restarted, restartSyscall bool
+	var handler = newTestTracerHandler()
+	handler.onEnter = func(pid int, curr *Syscall) {
+		if prev, ok := entered[pid]; ok {
+			t.Errorf("tracee %d enters %s in %s", pid, curr.GetName(), prev.GetName())
+		}
+		entered[pid] = curr
+		if curr.GetNR() == SYS_RESTART_SYSCALL {
+			restartSyscall = true
+		}
+	}
+	handler.onLeave = func(pid int, curr *Syscall, prev *Syscall) {
+		if prev == nil || prev != entered[pid] {
+			t.Errorf("tracee %d leaves %s without entering", pid, curr.GetName())
+			return
+		}
+		delete(entered, pid)
+		if curr.GetNR() != prev.GetNR() {
+			t.Errorf("tracee %d leaves %s in %s", pid, curr.GetName(), prev.GetName())
+		}
+		if err := curr.ReadRetval(); err != nil {
+			t.Error(err)
+		} else if curr.GetRetval().HasError_ERESTART() {
+			restarted = true
+		}
+	}
+	traceTestProg(t, handler, prog)
+
+	if handler.err != nil {
+		t.Fatal(handler.err)
+	}
+	for pid, ws := range handler.exited {
+		if ws.ExitStatus() != 0 {
+			t.Fatalf("tracee %d exited with return code %d", pid, ws.ExitStatus())
+		}
+	}
+	if len(handler.exited) != 1 {
+		t.Fatalf("exited: %d, want 1", len(handler.exited))
+	}
+	if !restarted {
+		t.Error("no syscall returns ERESTART*")
+	}
+	if !restartSyscall {
+		t.Error("no restart_syscall")
+	}
+}
+
+func TestTracerGroupStop(t *testing.T) {
+	var prog = buildTestProg(t, "group_stop")
+
+	var handler = newTestTracerHandler()
+	traceTestProg(t, handler, prog)
+
+	if handler.err != nil {
+		t.Fatal(handler.err)
+	}
+	if len(handler.exited) != 2 {
+		t.Fatalf("exited: %d, want 2", len(handler.exited))
+	}
+	for pid, ws := range handler.exited {
+		if ws.ExitStatus() != 0 {
+			t.Fatalf("tracee %d exited with return code %d", pid, ws.ExitStatus())
+		}
+	}
+}
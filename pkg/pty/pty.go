@@ -0,0 +1,84 @@
+// Package pty allocates pseudo-terminals and controls the terminal attributes, plz see pty(7) and termios(3).
+package pty
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// Open allocates a pseudo-terminal, returns the master (ptmx) and the slave (tty)
+func Open() (ptmx *os.File, tty *os.File, err error) {
+	ptmx, err = os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// unlockpt(3)
+	if err := unix.IoctlSetPointerInt(int(ptmx.Fd()), unix.TIOCSPTLCK, 0); err != nil {
+		ptmx.Close()
+		return nil, nil, fmt.Errorf("pty: TIOCSPTLCK: %s", err.Error())
+	}
+
+	// ptsname(3)
+	n, err := unix.IoctlGetInt(int(ptmx.Fd()), unix.TIOCGPTN)
+	if err != nil {
+		ptmx.Close()
+		return nil, nil, fmt.Errorf("pty: TIOCGPTN: %s", err.Error())
+	}
+
+	tty, err = os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		ptmx.Close()
+		return nil, nil, err
+	}
+	return ptmx, tty, nil
+}
+
+// IsTerminal reports whether the file is a terminal
+func IsTerminal(f *os.File) bool {
+	_, err := unix.IoctlGetTermios(int(f.Fd()), unix.TCGETS)
+	return err == nil
+}
+
+// InheritSize copies the window size of the terminal from to the terminal to
+func InheritSize(from *os.File, to *os.File) error {
+	ws, err := unix.IoctlGetWinsize(int(from.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return fmt.Errorf("pty: TIOCGWINSZ: %s", err.Error())
+	}
+	if err := unix.IoctlSetWinsize(int(to.Fd()), unix.TIOCSWINSZ, ws); err != nil {
+		return fmt.Errorf("pty: TIOCSWINSZ: %s", err.Error())
+	}
+	return nil
+}
+
+// MakeRaw puts the terminal into raw mode like cfmakeraw(3), returns the previous state to restore
+func MakeRaw(f *os.File) (*unix.Termios, error) {
+	termios, err := unix.IoctlGetTermios(int(f.Fd()), unix.TCGETS)
+	if err != nil {
+		return nil, fmt.Errorf("pty: TCGETS: %s", err.Error())
+	}
+	var state = *termios
+
+	termios.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	termios.Oflag &^= unix.OPOST
+	termios.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	termios.Cflag &^= unix.CSIZE | unix.PARENB
+	termios.Cflag |= unix.CS8
+	termios.Cc[unix.VMIN] = 1
+	termios.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(int(f.Fd()), unix.TCSETS, termios); err != nil {
+		return nil, fmt.Errorf("pty: TCSETS: %s", err.Error())
+	}
+	return &state, nil
+}
+
+// Restore restores the terminal to the state returned by MakeRaw
+func Restore(f *os.File, state *unix.Termios) error {
+	if err := unix.IoctlSetTermios(int(f.Fd()), unix.TCSETS, state); err != nil {
+		return fmt.Errorf("pty: TCSETS: %s", err.Error())
+	}
+	return nil
+}
@@ -1,21 +1,156 @@
 package gsandbox
 
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/souk4711/gsandbox/pkg/ptrace"
+)
+
 type Policy struct {
 	InheritEnv       string           `yaml:"env"`
 	ShareNetwork     string           `yaml:"share-net"`
+	TruncateOutput   string           `yaml:"truncate-output"`
 	WorkingDirectory string           `yaml:"work-dir"`
 	Limits           PolicyLimits     `yaml:"limits"`
-	AllowedSyscalls  []string         `yaml:"syscalls"`
+	AllowedSyscalls  []PolicySyscall  `yaml:"syscalls"`
+	CompatSyscalls   PolicyCompat     `yaml:"compat-syscalls"`
+	Clone            PolicyClone      `yaml:"clone"`
+	Exec             PolicyExec       `yaml:"exec"`
 	FileSystem       PolicyFileSystem `yaml:"fs"`
 }
 
 type PolicyLimits struct {
-	AS        string `yaml:"as,omitempty"`
-	CORE      string `yaml:"core,omitempty"`
-	CPU       string `yaml:"cpu,omitempty"`
-	FSIZE     string `yaml:"fsize,omitempty"`
-	NOFILE    string `yaml:"nofile,omitempty"`
-	WALLCLOCK string `yaml:"wallclock,omitempty"`
+	AS        string `yaml:"as,omitempty" json:"as,omitempty"`
+	CORE      string `yaml:"core,omitempty" json:"core,omitempty"`
+	CPU       string `yaml:"cpu,omitempty" json:"cpu,omitempty"`
+	FSIZE     string `yaml:"fsize,omitempty" json:"fsize,omitempty"`
+	NOFILE    string `yaml:"nofile,omitempty" json:"nofile,omitempty"`
+	WALLCLOCK string `yaml:"wallclock,omitempty" json:"wallclock,omitempty"`
+
+	Processes string                        `yaml:"processes,omitempty" json:"processes,omitempty"`
+	Files     string                        `yaml:"files,omitempty" json:"files,omitempty"`
+	Syscalls  map[string]PolicySyscallLimit `yaml:"syscalls,omitempty" json:"syscalls,omitempty"`
+	Stdout    string                        `yaml:"stdout,omitempty" json:"stdout,omitempty"`
+	Stderr    string                        `yaml:"stderr,omitempty" json:"stderr,omitempty"`
+}
+
+// merge overrides the limits with the specified ones of o
+func (l *PolicyLimits) merge(o PolicyLimits) {
+	for _, v := range []struct {
+		dst *string
+		src string
+	}{
+		{&l.AS, o.AS}, {&l.CORE, o.CORE}, {&l.CPU, o.CPU}, {&l.FSIZE, o.FSIZE}, {&l.NOFILE, o.NOFILE},
+		{&l.WALLCLOCK, o.WALLCLOCK}, {&l.Processes, o.Processes}, {&l.Files, o.Files}, {&l.Stdout, o.Stdout},
+		{&l.Stderr, o.Stderr},
+	} {
+		if v.src != "" {
+			*v.dst = v.src
+		}
+	}
+	if len(o.Syscalls) != 0 {
+		var syscalls = make(map[string]PolicySyscallLimit, len(l.Syscalls)+len(o.Syscalls))
+		for name, lim := range l.Syscalls {
+			syscalls[name] = lim
+		}
+		for name, lim := range o.Syscalls {
+			syscalls[name] = lim
+		}
+		l.Syscalls = syscalls
+	}
+}
+
+// isEmpty returns true if none of the limits is specified
+func (l *PolicyLimits) isEmpty() bool {
+	var strs = l.AS + l.CORE + l.CPU + l.FSIZE + l.NOFILE + l.WALLCLOCK + l.Processes + l.Files + l.Stdout + l.Stderr
+	return strs == "" && len(l.Syscalls) == 0
+}
+
+type PolicySyscallLimit struct {
+	Count string `yaml:"count,omitempty" json:"count,omitempty"`
+	Rate  string `yaml:"rate,omitempty" json:"rate,omitempty"`
+}
+
+// PolicySyscall is a syscall name, or a mapping with argument filters, e.g. {name: kill, args: [{index: 0, op: eq, value: 0}]}
+type PolicySyscall struct {
+	Name string             `yaml:"name"`
+	Args []PolicySyscallArg `yaml:"args,omitempty"`
+}
+
+type PolicySyscallArg struct {
+	Index  int      `yaml:"index"`
+	Op     string   `yaml:"op"`
+	Value  string   `yaml:"value,omitempty"`
+	Values []string `yaml:"values,omitempty"`
+	Mask   string   `yaml:"mask,omitempty"`
+}
+
+func (p *PolicySyscall) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		p.Name = node.Value
+		return nil
+	}
+
+	type plain PolicySyscall
+	return node.Decode((*plain)(p))
+}
+
+func (p *PolicySyscall) toArgFilters() ([]SyscallArgFilter, error) {
+	var filters = make([]SyscallArgFilter, 0, len(p.Args))
+	for _, arg := range p.Args {
+		var filter = SyscallArgFilter{Index: arg.Index, Op: arg.Op}
+		if arg.Index < 0 || arg.Index > 5 {
+			return nil, fmt.Errorf("invalid index(%d)", arg.Index)
+		}
+		filter.Width, filter.Signed = ptrace.LookupParamType(p.Name, arg.Index).Width()
+
+		var values []string
+		switch arg.Op {
+		case ARG_OP_EQ, ARG_OP_NE, ARG_OP_LT, ARG_OP_LE, ARG_OP_GT, ARG_OP_GE, ARG_OP_MASKED_EQ:
+			values = []string{arg.Value}
+		case ARG_OP_IN, ARG_OP_NOT_IN:
+			values = arg.Values
+		default:
+			return nil, fmt.Errorf("invalid op(%s)", arg.Op)
+		}
+		for _, str := range values {
+			if v, err := parseSyscallArgValue(str, filter.Width); err != nil {
+				return nil, err
+			} else {
+				filter.Values = append(filter.Values, v)
+			}
+		}
+		if arg.Op == ARG_OP_MASKED_EQ {
+			if v, err := parseSyscallArgValue(arg.Mask, filter.Width); err != nil {
+				return nil, err
+			} else {
+				filter.Mask = v
+			}
+		}
+
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+// PolicyCompat specifies the allowed syscalls per compat ABI, e.g. i386 on x86-64, others are denied
+type PolicyCompat map[string][]string
+
+type PolicyClone struct {
+	DeniedFlags []string `yaml:"denied-flags"`
+}
+
+type PolicyExec struct {
+	MaxCount string           `yaml:"max-count,omitempty"`
+	MaxDepth string           `yaml:"max-depth,omitempty"`
+	Rules    []PolicyExecRule `yaml:"rules"`
+}
+
+type PolicyExecRule struct {
+	Path string   `yaml:"path"`
+	Args []string `yaml:"args"`
 }
 
 type PolicyFileSystem struct {
@@ -0,0 +1,75 @@
+package gsandbox
+
+import (
+	"sort"
+	"time"
+)
+
+// Report contains the detailed statistics about an exited command, available after a call to
+// Executor#Run if Executor#WithDetailedReport is called
+type Report struct {
+	Syscalls     []ReportSyscall `json:"syscalls"`     // syscall statistics, like `strace -c`, ordered by time
+	BytesRead    uint64          `json:"bytesRead"`    // bytes read by read(2), recv(2) and the like
+	BytesWritten uint64          `json:"bytesWritten"` // bytes written by write(2), send(2) and the like
+	FilesOpened  []string        `json:"filesOpened"`  // files opened, in order of the first open
+	Forks        int             `json:"forks"`        // processes created, excluding threads
+
+	// collected
+	syscalls    map[string]*ReportSyscall
+	filesOpened map[string]struct{}
+}
+
+type ReportSyscall struct {
+	Name   string        `json:"name"`   // syscall name
+	Calls  int           `json:"calls"`  // number of calls
+	Errors int           `json:"errors"` // number of calls which return an error
+	Time   time.Duration `json:"time"`   // cumulative time, from syscall-enter-stop to syscall-exit-stop
+}
+
+func newReport() *Report {
+	return &Report{
+		Syscalls: make([]ReportSyscall, 0), FilesOpened: make([]string, 0),
+		syscalls: make(map[string]*ReportSyscall), filesOpened: make(map[string]struct{}),
+	}
+}
+
+func (r *Report) getSyscall(name string) *ReportSyscall {
+	s, ok := r.syscalls[name]
+	if !ok {
+		s = &ReportSyscall{Name: name}
+		r.syscalls[name] = s
+	}
+	return s
+}
+
+func (r *Report) addSyscallCall(name string) {
+	r.getSyscall(name).Calls += 1
+}
+
+func (r *Report) addSyscallTime(name string, elapsed time.Duration, failed bool) {
+	var s = r.getSyscall(name)
+	s.Time += elapsed
+	if failed {
+		s.Errors += 1
+	}
+}
+
+func (r *Report) addFileOpened(fullpath string) {
+	if _, ok := r.filesOpened[fullpath]; !ok {
+		r.filesOpened[fullpath] = struct{}{}
+		r.FilesOpened = append(r.FilesOpened, fullpath)
+	}
+}
+
+func (r *Report) finish() {
+	r.Syscalls = r.Syscalls[:0]
+	for _, s := range r.syscalls {
+		r.Syscalls = append(r.Syscalls, *s)
+	}
+	sort.Slice(r.Syscalls, func(i, j int) bool {
+		if r.Syscalls[i].Time != r.Syscalls[j].Time {
+			return r.Syscalls[i].Time > r.Syscalls[j].Time
+		}
+		return r.Syscalls[i].Name < r.Syscalls[j].Name
+	})
+}
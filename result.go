@@ -14,4 +14,27 @@ type Result struct {
 	SystemTime time.Duration `json:"systemTime"` // system CPU time used
 	UserTime   time.Duration `json:"userTime"`   // user CPU time used
 	Maxrss     int64         `json:"maxrss"`     // maximum resident set size (in kilobytes)
+
+	StdoutBytes uint64 `json:"stdoutBytes"` // bytes written to stdout, including the truncated ones
+	StderrBytes uint64 `json:"stderrBytes"` // bytes written to stderr, including the truncated ones
+
+	Stdout *CapturedOutput `json:"stdout,omitempty"` // captured stdout, plz see Executor#WithCapturedOutput
+	Stderr *CapturedOutput `json:"stderr,omitempty"` // captured stderr, plz see Executor#WithCapturedOutput
+
+	Processes []ProcessResult `json:"processes"` // exit info of every process, in order of exit
+
+	Report *Report `json:"report,omitempty"` // detailed statistics, plz see Executor#WithDetailedReport
+}
+
+type ProcessResult struct {
+	Pid        int           `json:"pid"`        // process id, in the host pid namespace
+	Ppid       int           `json:"ppid"`       // parent process id, in the host pid namespace
+	Argv       []string      `json:"argv"`       // command line arguments of the last execve(2)
+	StartTime  time.Time     `json:"startTime"`  // when process started
+	FinishTime time.Time     `json:"finishTime"` // when process finished
+	ExitCode   int           `json:"exitCode"`   // exit code or signal number that caused an exit
+	Signal     string        `json:"signal"`     // signal that caused an exit, if any
+	SystemTime time.Duration `json:"systemTime"` // system CPU time used, including the reaped descendants
+	UserTime   time.Duration `json:"userTime"`   // user CPU time used, including the reaped descendants
+	Maxrss     int64         `json:"maxrss"`     // maximum resident set size (in kilobytes)
 }
@@ -1,8 +1,11 @@
 package gsandbox
 
 import (
+	"fmt"
 	"os"
+	"regexp"
 	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
@@ -12,12 +15,17 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/souk4711/gsandbox/pkg/fsfilter"
+	"github.com/souk4711/gsandbox/pkg/ptrace"
 )
 
 type Sandbox struct {
 	policy           Policy
 	logger           logr.Logger
+	metrics          MetricsHook
+	tracerProvider   TracerProvider
 	runningExecutors map[*Executor]struct{}
+	canceled         bool
+	mu               sync.Mutex // guards runningExecutors and canceled, executors may run concurrently
 }
 
 func NewSandbox() *Sandbox {
@@ -33,9 +41,27 @@ func (s *Sandbox) WithLogger(logger logr.Logger) *Sandbox {
 	return s
 }
 
+// WithMetrics reports the runs of the executors to the hook, plz see NewMetrics
+func (s *Sandbox) WithMetrics(hook MetricsHook) *Sandbox {
+	s.metrics = hook
+	return s
+}
+
+// WithTracerProvider emits the spans of the executors, plz see Executor#WithTracerProvider
+func (s *Sandbox) WithTracerProvider(tp TracerProvider) *Sandbox {
+	s.tracerProvider = tp
+	return s
+}
+
 func (s *Sandbox) NewExecutor(prog string, args []string) *Executor {
 	var policy = s.policy
 	var executor = NewExecutor(prog, args).WithLogger(s.logger)
+	if s.metrics != nil {
+		executor.WithMetrics(s.metrics)
+	}
+	if s.tracerProvider != nil {
+		executor.WithTracerProvider(s.tracerProvider)
+	}
 
 	// env
 	if policy.InheritEnv == ENABLED {
@@ -54,6 +80,9 @@ func (s *Sandbox) NewExecutor(prog string, args []string) *Executor {
 	if policy.ShareNetwork == ENABLED {
 		executor.SetFlag(FLAG_SHARE_NETWORK, ENABLED)
 	}
+	if policy.TruncateOutput == ENABLED {
+		executor.SetFlag(FLAG_TRUNCATE_OUTPUT, ENABLED)
+	}
 
 	// set limits
 	var limits = Limits{}
@@ -77,11 +106,70 @@ func (s *Sandbox) NewExecutor(prog string, args []string) *Executor {
 		var v = uint64(duration.Seconds())
 		limits.LimitWallClockTime = &v
 	}
+	if v, err := strconv.ParseUint(policy.Limits.Processes, 10, 64); err == nil {
+		limits.LimitProcessCount = &v
+	}
+	if v, err := strconv.ParseUint(policy.Limits.Files, 10, 64); err == nil {
+		limits.LimitFileCount = &v
+	}
+	if len(policy.Limits.Syscalls) != 0 {
+		limits.LimitSyscalls = make(map[string]SyscallLimit)
+		for name, lim := range policy.Limits.Syscalls {
+			var syscallLimit = SyscallLimit{}
+			if v, err := strconv.ParseUint(lim.Count, 10, 64); err == nil {
+				syscallLimit.Count = &v
+			}
+			if v, err := strconv.ParseUint(lim.Rate, 10, 64); err == nil {
+				syscallLimit.Rate = &v
+			}
+			limits.LimitSyscalls[name] = syscallLimit
+		}
+	}
+	if v, err := humanize.ParseBytes(policy.Limits.Stdout); err == nil {
+		limits.LimitStdout = &v
+	}
+	if v, err := humanize.ParseBytes(policy.Limits.Stderr); err == nil {
+		limits.LimitStderr = &v
+	}
+	if v, err := strconv.ParseUint(policy.Exec.MaxCount, 10, 64); err == nil {
+		limits.LimitExecCount = &v
+	}
+	if v, err := strconv.ParseUint(policy.Exec.MaxDepth, 10, 64); err == nil {
+		limits.LimitExecDepth = &v
+	}
 	executor.SetLimits(limits)
 
 	// set allowed syscalls
-	for _, syscall := range policy.AllowedSyscalls {
-		executor.AddAllowedSyscall(syscall)
+	var syscalls, _ = expandPolicySyscalls(policy.AllowedSyscalls)
+	for _, syscall := range syscalls {
+		if len(syscall.Args) == 0 {
+			executor.AddAllowedSyscall(syscall.Name)
+		} else if filters, err := syscall.toArgFilters(); err == nil {
+			executor.AddAllowedSyscallWithArgFilters(syscall.Name, filters)
+		}
+	}
+
+	// set allowed compat syscalls
+	for arch, syscalls := range policy.CompatSyscalls {
+		for _, syscall := range syscalls {
+			executor.AddAllowedCompatSyscall(arch, syscall)
+		}
+	}
+
+	// set denied clone flags
+	for _, name := range policy.Clone.DeniedFlags {
+		if flag, ok := ptrace.LookupFlagClone(name); ok {
+			executor.AddDeniedCloneFlag(uint64(flag))
+		}
+	}
+
+	// set exec rules
+	for _, rule := range policy.Exec.Rules {
+		var args = make([]*regexp.Regexp, 0, len(rule.Args))
+		for _, arg := range rule.Args {
+			args = append(args, regexp.MustCompile(arg))
+		}
+		executor.AddExecRule(rule.Path, args)
 	}
 
 	// set allowed files with perm
@@ -95,12 +183,24 @@ func (s *Sandbox) NewExecutor(prog string, args []string) *Executor {
 }
 
 func (s *Sandbox) Cleanup() {
-	for e := range s.runningExecutors {
-		_ = syscall.Kill(-e.cmd.Process.Pid, syscall.SIGKILL)
+	var pids = s.Cancel()
+	for _, pid := range pids {
+		_, _ = syscall.Wait4(-pid, nil, syscall.WALL, nil)
 	}
+}
+
+// Cancel kills the running executors, and the ones started later, returns the pids of the killed ones
+func (s *Sandbox) Cancel() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.canceled = true
+	var pids = make([]int, 0, len(s.runningExecutors))
 	for e := range s.runningExecutors {
-		_, _ = syscall.Wait4(-e.cmd.Process.Pid, nil, syscall.WALL, nil)
+		_ = syscall.Kill(-e.cmd.Process.Pid, syscall.SIGKILL)
+		pids = append(pids, e.cmd.Process.Pid)
 	}
+	return pids
 }
 
 func (s *Sandbox) LoadPolicyFromFile(filePath string) error {
@@ -115,13 +215,59 @@ func (s *Sandbox) LoadPolicyFromFile(filePath string) error {
 }
 
 func (s *Sandbox) LoadPolicyFromData(data []byte) error {
-	return yaml.Unmarshal(data, &s.policy)
+	if err := yaml.Unmarshal(data, &s.policy); err != nil {
+		return err
+	}
+	for _, syscall := range s.policy.AllowedSyscalls {
+		if syscall.Name == "" || syscall.Name == "!" {
+			return fmt.Errorf("policy: invalid syscall name(%s)", syscall.Name)
+		}
+	}
+	syscalls, err := expandPolicySyscalls(s.policy.AllowedSyscalls)
+	if err != nil {
+		return fmt.Errorf("policy: %s", err.Error())
+	}
+	for _, syscall := range syscalls {
+		if _, err := syscall.toArgFilters(); err != nil {
+			return fmt.Errorf("policy: invalid syscall args(%s): %s", syscall.Name, err.Error())
+		}
+	}
+	for arch := range s.policy.CompatSyscalls {
+		if !ptrace.IsCompatArch(arch) {
+			return fmt.Errorf("policy: invalid compat arch(%s)", arch)
+		}
+	}
+	for _, name := range s.policy.Clone.DeniedFlags {
+		if _, ok := ptrace.LookupFlagClone(name); !ok {
+			return fmt.Errorf("policy: invalid clone flag(%s)", name)
+		}
+	}
+	for _, rule := range s.policy.Exec.Rules {
+		if rule.Path == "" || rule.Path[0:1] != "/" {
+			return fmt.Errorf("policy: invalid exec path(%s)", rule.Path)
+		}
+		for _, arg := range rule.Args {
+			if _, err := regexp.Compile(arg); err != nil {
+				return fmt.Errorf("policy: invalid exec args(%s)", arg)
+			}
+		}
+	}
+	return nil
 }
 
 func (s *Sandbox) addRunningExecutor(e *Executor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.runningExecutors[e] = struct{}{}
+	if s.canceled {
+		_ = syscall.Kill(-e.cmd.Process.Pid, syscall.SIGKILL)
+	}
 }
 
 func (s *Sandbox) removeRunningExecutor(e *Executor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	delete(s.runningExecutors, e)
 }
@@ -0,0 +1,431 @@
+package gsandbox
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+)
+
+// run states
+const (
+	RUN_STATE_QUEUED   = "queued"   // waiting for a free slot, plz see Server#MaxConcurrency
+	RUN_STATE_RUNNING  = "running"  // the executor is running
+	RUN_STATE_FINISHED = "finished" // the result is available
+	RUN_STATE_CANCELED = "canceled" // canceled by the client, the result is available if it has been running
+)
+
+const (
+	// the size of the head and the tail of stdout/stderr kept in the result
+	serverCapturedOutputHeadSize = 32 * 1024
+	serverCapturedOutputTailSize = 32 * 1024
+
+	// the number of finished runs kept, the oldest one is removed once exceeded
+	serverMaxFinishedRuns = 1024
+)
+
+// RunRequest submits a run to the server, either Policy or PolicyData is required
+type RunRequest struct {
+	Prog       string       `json:"prog"`
+	Args       []string     `json:"args"`
+	Policy     string       `json:"policy"`     // name of a policy, plz see Server#WithPolicyLoader
+	PolicyData string       `json:"policyData"` // inline policy configuration, in YAML
+	Limits     PolicyLimits `json:"limits"`     // overrides the limits of the policy
+	Dir        string       `json:"dir"`        // working directory of the process
+	Stdin      string       `json:"stdin"`      // standard input of the process
+}
+
+type RunStatus struct {
+	ID          string    `json:"id"`
+	State       string    `json:"state"`
+	SubmittedAt time.Time `json:"submittedAt"`
+	Result      *Result   `json:"result,omitempty"` // available once finished
+}
+
+// Server runs the submitted programs with an HTTP/JSON API:
+//
+//	POST   /runs             submit a RunRequest, returns the RunStatus
+//	GET    /runs             list the RunStatus of the runs, excluding results
+//	GET    /runs/ID          get the RunStatus, including the result and the captured output once finished
+//	GET    /runs/ID/events   stream the RunStatus on each state change, in JSON Lines
+//	DELETE /runs/ID          cancel a queued or running run, or remove a finished one
+//	GET    /metrics          export the metrics in the Prometheus text format, if Server#WithMetrics is called
+//
+// At most MaxConcurrency runs are running at the same time, and at most MaxQueue runs are queued, the
+// submission is rejected with 429 Too Many Requests once exceeded.
+//
+// RunRequest#PolicyData and RunRequest#Limits loosen the sandbox at the will of the client, they are rejected
+// with 403 Forbidden unless AllowInlinePolicy is set, e.g. the clients are trusted.
+type Server struct {
+	MaxConcurrency    int
+	MaxQueue          int
+	AllowInlinePolicy bool
+
+	logger       logr.Logger
+	metrics      *Metrics
+	policyLoader func(name string) ([]byte, error)
+	policies     map[string]Policy // parsed policies by name
+	runs         map[string]*serverRun
+	finished     []string // ids of the finished runs, in order of finish
+	queued       int
+	slots        chan struct{}
+	mu           sync.Mutex
+}
+
+type serverRun struct {
+	status   RunStatus
+	changed  chan struct{} // closed and replaced on each state change
+	canceled chan struct{} // closed once canceled while queued, the run stops waiting for a slot
+	sandbox  *Sandbox
+}
+
+func NewServer(maxConcurrency int, maxQueue int) *Server {
+	var s = Server{
+		MaxConcurrency: maxConcurrency,
+		MaxQueue:       maxQueue,
+		logger:         funcr.New(func(_, _ string) {}, funcr.Options{}),
+		policyLoader: func(name string) ([]byte, error) {
+			return nil, fmt.Errorf("policy(%s) not found", name)
+		},
+		policies: make(map[string]Policy),
+		runs:     make(map[string]*serverRun),
+		slots:    make(chan struct{}, maxConcurrency),
+	}
+	return &s
+}
+
+func (s *Server) WithLogger(logger logr.Logger) *Server {
+	s.logger = logger
+	return s
+}
+
+// WithMetrics collects the metrics of the runs, and exports them as /metrics
+func (s *Server) WithMetrics(metrics *Metrics) *Server {
+	s.metrics = metrics
+	return s
+}
+
+// WithPolicyLoader resolves the `policy` of the requests, the policy is loaded once and then cached
+func (s *Server) WithPolicyLoader(loader func(name string) ([]byte, error)) *Server {
+	s.policyLoader = loader
+	return s
+}
+
+// Shutdown cancels the queued and running runs
+func (s *Server) Shutdown() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, run := range s.runs {
+		s.cancel(run)
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var path = strings.Trim(r.URL.Path, "/")
+	var parts = strings.Split(path, "/")
+	switch {
+	case path == "metrics" && r.Method == http.MethodGet && s.metrics != nil:
+		s.metrics.ServeHTTP(w, r)
+	case path == "runs" && r.Method == http.MethodPost:
+		s.handleSubmit(w, r)
+	case path == "runs" && r.Method == http.MethodGet:
+		s.handleList(w, r)
+	case len(parts) == 2 && parts[0] == "runs" && r.Method == http.MethodGet:
+		s.handleGet(w, r, parts[1])
+	case len(parts) == 2 && parts[0] == "runs" && r.Method == http.MethodDelete:
+		s.handleDelete(w, r, parts[1])
+	case len(parts) == 3 && parts[0] == "runs" && parts[2] == "events" && r.Method == http.MethodGet:
+		s.handleEvents(w, r, parts[1])
+	default:
+		writeServerError(w, http.StatusNotFound, fmt.Errorf("not found: %s %s", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var req RunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeServerError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %s", err.Error()))
+		return
+	}
+	if req.Prog == "" {
+		writeServerError(w, http.StatusBadRequest, fmt.Errorf("invalid request: prog is required"))
+		return
+	}
+	if !s.AllowInlinePolicy && (req.PolicyData != "" || !req.Limits.isEmpty()) {
+		writeServerError(w, http.StatusForbidden, fmt.Errorf("forbidden: policyData and limits are not allowed"))
+		return
+	}
+
+	// policy
+	var sandbox = NewSandbox()
+	if policy, err := s.loadPolicy(req); err != nil {
+		writeServerError(w, http.StatusBadRequest, err)
+		return
+	} else {
+		sandbox.policy = policy
+		sandbox.policy.Limits.merge(req.Limits)
+	}
+
+	// admission control
+	s.mu.Lock()
+	if s.queued >= s.MaxQueue {
+		s.mu.Unlock()
+		writeServerError(w, http.StatusTooManyRequests, fmt.Errorf("too many runs queued: %d", s.queued))
+		return
+	}
+	var run = &serverRun{
+		status:   RunStatus{ID: newServerRunID(), State: RUN_STATE_QUEUED, SubmittedAt: time.Now()},
+		changed:  make(chan struct{}),
+		canceled: make(chan struct{}),
+		sandbox:  sandbox,
+	}
+	sandbox.WithLogger(s.logger.WithName(run.status.ID))
+	if s.metrics != nil {
+		sandbox.WithMetrics(s.metrics)
+	}
+	s.runs[run.status.ID] = run
+	s.queued += 1
+	var status = run.status
+	s.mu.Unlock()
+
+	s.logger.V(LOG_LEVEL_RESULT).Info("server: Queued", "run", status.ID, "prog", req.Prog, "args", req.Args)
+	go s.run(run, req)
+	writeServerJSON(w, http.StatusAccepted, status)
+}
+
+func (s *Server) handleList(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	var statuses = make([]RunStatus, 0, len(s.runs))
+	for _, run := range s.runs {
+		var status = run.status
+		status.Result = nil
+		statuses = append(statuses, status)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].SubmittedAt.Before(statuses[j].SubmittedAt)
+	})
+	writeServerJSON(w, http.StatusOK, statuses)
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, _ *http.Request, id string) {
+	s.mu.Lock()
+	var run, ok = s.runs[id]
+	var status RunStatus
+	if ok {
+		status = run.status
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeServerError(w, http.StatusNotFound, fmt.Errorf("run(%s) not found", id))
+		return
+	}
+	writeServerJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, _ *http.Request, id string) {
+	s.mu.Lock()
+	var run, ok = s.runs[id]
+	var status RunStatus
+	if ok {
+		switch run.status.State {
+		case RUN_STATE_QUEUED, RUN_STATE_RUNNING:
+			s.cancel(run)
+		default:
+			s.remove(id)
+		}
+		status = run.status
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeServerError(w, http.StatusNotFound, fmt.Errorf("run(%s) not found", id))
+		return
+	}
+	writeServerJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request, id string) {
+	s.mu.Lock()
+	var run, ok = s.runs[id]
+	s.mu.Unlock()
+	if !ok {
+		writeServerError(w, http.StatusNotFound, fmt.Errorf("run(%s) not found", id))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	var flusher, _ = w.(http.Flusher)
+	for {
+		s.mu.Lock()
+		var status, changed = run.status, run.changed
+		s.mu.Unlock()
+
+		var data, _ = json.Marshal(status)
+		if _, err := w.Write(append(data, '\n')); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if status.State == RUN_STATE_FINISHED || status.State == RUN_STATE_CANCELED {
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// run waits for a free slot, then runs the executor
+func (s *Server) run(run *serverRun, req RunRequest) {
+	select {
+	case s.slots <- struct{}{}:
+	case <-run.canceled: // the queued count is released by Server#cancel
+		return
+	}
+	defer func() {
+		<-s.slots
+	}()
+
+	s.mu.Lock()
+	if run.status.State == RUN_STATE_CANCELED { // canceled while taking the slot
+		s.mu.Unlock()
+		return
+	}
+	s.queued -= 1
+	s.setState(run, RUN_STATE_RUNNING)
+	s.mu.Unlock()
+
+	var executor = run.sandbox.NewExecutor(req.Prog, req.Args)
+	if req.Dir != "" {
+		executor.Dir = req.Dir
+	}
+	executor.Stdin = strings.NewReader(req.Stdin)
+	executor.WithCapturedOutput(serverCapturedOutputHeadSize, serverCapturedOutputTailSize)
+	executor.Run()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result = executor.Result
+	run.status.Result = &result
+	if run.status.State != RUN_STATE_CANCELED {
+		s.setState(run, RUN_STATE_FINISHED)
+	} else {
+		s.setState(run, RUN_STATE_CANCELED) // notify the result
+	}
+	s.logger.V(LOG_LEVEL_RESULT).Info("server: Finished", "run", run.status.ID, "state", run.status.State, "status", result.Status)
+
+	s.addFinished(run)
+}
+
+// loadPolicy returns the inline policy, or the one loaded by the policy loader
+func (s *Server) loadPolicy(req RunRequest) (Policy, error) {
+	if (req.Policy == "") == (req.PolicyData == "") {
+		return Policy{}, fmt.Errorf("invalid request: exactly one of policy and policyData is required")
+	}
+
+	if req.PolicyData != "" {
+		var sandbox = NewSandbox()
+		if err := sandbox.LoadPolicyFromData([]byte(req.PolicyData)); err != nil {
+			return Policy{}, fmt.Errorf("invalid request: %s", err.Error())
+		}
+		return sandbox.policy, nil
+	}
+
+	s.mu.Lock()
+	var policy, ok = s.policies[req.Policy]
+	s.mu.Unlock()
+	if ok {
+		return policy, nil
+	}
+
+	data, err := s.policyLoader(req.Policy)
+	if err != nil {
+		return Policy{}, fmt.Errorf("invalid request: %s", err.Error())
+	}
+	var sandbox = NewSandbox()
+	if err := sandbox.LoadPolicyFromData(data); err != nil {
+		return Policy{}, fmt.Errorf("invalid request: %s", err.Error())
+	}
+
+	s.mu.Lock()
+	s.policies[req.Policy] = sandbox.policy
+	s.mu.Unlock()
+	return sandbox.policy, nil
+}
+
+// cancel requires s.mu to be held
+func (s *Server) cancel(run *serverRun) {
+	switch run.status.State {
+	case RUN_STATE_QUEUED:
+		s.queued -= 1
+		s.setState(run, RUN_STATE_CANCELED)
+		close(run.canceled)
+		s.addFinished(run)
+	case RUN_STATE_RUNNING:
+		s.setState(run, RUN_STATE_CANCELED)
+		run.sandbox.Cancel()
+	}
+}
+
+// addFinished requires s.mu to be held
+func (s *Server) addFinished(run *serverRun) {
+	if _, ok := s.runs[run.status.ID]; !ok { // removed
+		return
+	}
+	s.finished = append(s.finished, run.status.ID)
+	if len(s.finished) > serverMaxFinishedRuns {
+		s.remove(s.finished[0])
+	}
+}
+
+// remove requires s.mu to be held
+func (s *Server) remove(id string) {
+	delete(s.runs, id)
+	for i, v := range s.finished {
+		if v == id {
+			s.finished = append(s.finished[:i], s.finished[i+1:]...)
+			break
+		}
+	}
+}
+
+// setState requires s.mu to be held
+func (s *Server) setState(run *serverRun, state string) {
+	run.status.State = state
+	close(run.changed)
+	run.changed = make(chan struct{})
+}
+
+func newServerRunID() string {
+	var b = make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func writeServerJSON(w http.ResponseWriter, code int, v any) {
+	var data, _ = json.Marshal(v)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(append(data, '\n'))
+}
+
+func writeServerError(w http.ResponseWriter, code int, err error) {
+	writeServerJSON(w, code, map[string]string{"error": err.Error()})
+}
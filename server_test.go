@@ -0,0 +1,64 @@
+package gsandbox
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// submitTestRun posts the request to /runs, the status is decoded once accepted
+func submitTestRun(t *testing.T, s *Server, body string) (int, RunStatus) {
+	t.Helper()
+
+	var w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/runs", strings.NewReader(body)))
+
+	var status RunStatus
+	if w.Code == http.StatusAccepted {
+		if err := json.NewDecoder(w.Body).Decode(&status); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return w.Code, status
+}
+
+func TestServerCancelQueued(t *testing.T) {
+	var s = NewServer(0, 1) // no slot, the runs stay queued
+	s.WithPolicyLoader(func(name string) ([]byte, error) {
+		return []byte(`syscalls: ["@system-service"]`), nil
+	})
+	defer s.Shutdown()
+
+	var code, status = submitTestRun(t, s, `{"prog": "/bin/true", "policy": "true"}`)
+	if code != http.StatusAccepted {
+		t.Fatalf("code: %d, want %d", code, http.StatusAccepted)
+	}
+	if code, _ := submitTestRun(t, s, `{"prog": "/bin/true", "policy": "true"}`); code != http.StatusTooManyRequests {
+		t.Fatalf("code: %d, want %d", code, http.StatusTooManyRequests)
+	}
+
+	var w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/runs/"+status.ID, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("code: %d, want %d", w.Code, http.StatusOK)
+	}
+	if code, _ := submitTestRun(t, s, `{"prog": "/bin/true", "policy": "true"}`); code != http.StatusAccepted {
+		t.Fatalf("code: %d, want %d, the canceled run still holds the queue", code, http.StatusAccepted)
+	}
+}
+
+func TestServerInlinePolicyForbidden(t *testing.T) {
+	var s = NewServer(1, 1)
+	defer s.Shutdown()
+
+	for _, body := range []string{
+		`{"prog": "/bin/true", "policyData": "syscalls: []"}`,
+		`{"prog": "/bin/true", "policy": "default", "limits": {"wallclock": "1h"}}`,
+	} {
+		if code, _ := submitTestRun(t, s, body); code != http.StatusForbidden {
+			t.Fatalf("%s: code: %d, want %d", body, code, http.StatusForbidden)
+		}
+	}
+}
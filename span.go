@@ -0,0 +1,171 @@
+package gsandbox
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// span names
+const (
+	SPAN_RUN              = "gsandbox.run"              // Executor#Run, the parent of the spans below
+	SPAN_SETUP_NAMESPACES = "gsandbox.setup-namespaces" // build the clone flags and the uid/gid mappings
+	SPAN_EXEC             = "gsandbox.exec"             // clone(2) into the new namespaces, then execve(2)
+	SPAN_SET_RLIMITS      = "gsandbox.set-rlimits"      // prlimit(2) the tracee
+	SPAN_BUILD_FSFILTER   = "gsandbox.build-fsfilter"   // build the file access rules
+	SPAN_PTRACE_LOOP      = "gsandbox.ptrace-loop"      // trace the tracees until the command exits
+	SPAN_TEARDOWN         = "gsandbox.teardown"         // reap the tracees, wait for copying stdin/stdout/stderr
+)
+
+// span event names
+const (
+	SPAN_EVENT_VIOLATION = "gsandbox.violation" // a syscall is denied
+	SPAN_EVENT_NEW_CHILD = "gsandbox.new-child" // a process or thread is created
+)
+
+// SpanAttribute is a key-value pair of a span or a span event, the value is a string, an int, or a bool
+type SpanAttribute struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+// TracerProvider creates the tracers, like OpenTelemetry's trace.TracerProvider, so an OpenTelemetry SDK can be
+// used with a thin adapter, plz see Sandbox#WithTracerProvider and NewInMemoryTracerProvider
+type TracerProvider interface {
+	Tracer(name string) SpanTracer
+}
+
+// SpanTracer starts the spans, like OpenTelemetry's trace.Tracer
+type SpanTracer interface {
+	// Start starts a span as a child of the span in ctx, if any, returns a ctx containing the new span
+	Start(ctx context.Context, name string, attrs ...SpanAttribute) (context.Context, Span)
+}
+
+// Span is an operation, like OpenTelemetry's trace.Span
+type Span interface {
+	SetAttributes(attrs ...SpanAttribute)
+	AddEvent(name string, attrs ...SpanAttribute)
+	RecordError(err error)
+	End()
+}
+
+// the name of the tracer used by the executors
+const spanTracerName = "github.com/souk4711/gsandbox"
+
+// noopSpan is used if no TracerProvider is specified
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...SpanAttribute)    {}
+func (noopSpan) AddEvent(string, ...SpanAttribute) {}
+func (noopSpan) RecordError(error)                 {}
+func (noopSpan) End()                              {}
+
+// InMemoryTracerProvider records the ended spans in memory, e.g. to test the instrumentation
+type InMemoryTracerProvider struct {
+	spans  []InMemorySpan
+	nextID uint64
+	mu     sync.Mutex
+}
+
+// InMemorySpan is an ended span recorded by InMemoryTracerProvider
+type InMemorySpan struct {
+	ID         uint64              `json:"id"`
+	ParentID   uint64              `json:"parentId,omitempty"` // 0 if it's a root span
+	Tracer     string              `json:"tracer"`
+	Name       string              `json:"name"`
+	StartTime  time.Time           `json:"startTime"`
+	EndTime    time.Time           `json:"endTime"`
+	Attributes []SpanAttribute     `json:"attributes,omitempty"`
+	Events     []InMemorySpanEvent `json:"events,omitempty"`
+	Errors     []string            `json:"errors,omitempty"`
+}
+
+type InMemorySpanEvent struct {
+	Name       string          `json:"name"`
+	Time       time.Time       `json:"time"`
+	Attributes []SpanAttribute `json:"attributes,omitempty"`
+}
+
+type inMemorySpanTracer struct {
+	provider *InMemoryTracerProvider
+	name     string
+}
+
+type inMemorySpan struct {
+	provider *InMemoryTracerProvider
+	span     InMemorySpan
+	ended    bool
+}
+
+type inMemorySpanKey struct{}
+
+func NewInMemoryTracerProvider() *InMemoryTracerProvider {
+	return &InMemoryTracerProvider{}
+}
+
+func (p *InMemoryTracerProvider) Tracer(name string) SpanTracer {
+	return &inMemorySpanTracer{provider: p, name: name}
+}
+
+// Spans returns the ended spans, in order of end
+func (p *InMemoryTracerProvider) Spans() []InMemorySpan {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]InMemorySpan{}, p.spans...)
+}
+
+// Reset removes the recorded spans
+func (p *InMemoryTracerProvider) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.spans = nil
+}
+
+func (t *inMemorySpanTracer) Start(ctx context.Context, name string, attrs ...SpanAttribute) (context.Context, Span) {
+	t.provider.mu.Lock()
+	t.provider.nextID += 1
+	var id = t.provider.nextID
+	t.provider.mu.Unlock()
+
+	var span = &inMemorySpan{provider: t.provider}
+	span.span = InMemorySpan{ID: id, Tracer: t.name, Name: name, StartTime: time.Now(), Attributes: attrs}
+	if parent, ok := ctx.Value(inMemorySpanKey{}).(*inMemorySpan); ok {
+		span.span.ParentID = parent.span.ID
+	}
+	return context.WithValue(ctx, inMemorySpanKey{}, span), span
+}
+
+func (s *inMemorySpan) SetAttributes(attrs ...SpanAttribute) {
+	s.provider.mu.Lock()
+	defer s.provider.mu.Unlock()
+
+	s.span.Attributes = append(s.span.Attributes, attrs...)
+}
+
+func (s *inMemorySpan) AddEvent(name string, attrs ...SpanAttribute) {
+	s.provider.mu.Lock()
+	defer s.provider.mu.Unlock()
+
+	s.span.Events = append(s.span.Events, InMemorySpanEvent{Name: name, Time: time.Now(), Attributes: attrs})
+}
+
+func (s *inMemorySpan) RecordError(err error) {
+	s.provider.mu.Lock()
+	defer s.provider.mu.Unlock()
+
+	s.span.Errors = append(s.span.Errors, err.Error())
+}
+
+func (s *inMemorySpan) End() {
+	s.provider.mu.Lock()
+	defer s.provider.mu.Unlock()
+
+	if s.ended {
+		return
+	}
+	s.ended = true
+	s.span.EndTime = time.Now()
+	s.provider.spans = append(s.provider.spans, s.span)
+}
@@ -0,0 +1,53 @@
+package gsandbox
+
+import (
+	"testing"
+)
+
+func TestExecutorSpans(t *testing.T) {
+	var prog = buildTestProg(t, "violation")
+	var tp = NewInMemoryTracerProvider()
+	var executor = newTestSandbox(t, prog).WithTracerProvider(tp).NewExecutor(prog, nil)
+	executor.Run()
+	if r := executor.Result; r.Status != StatusViolation {
+		t.Fatalf("status: %s, reason: %s, exitCode: %d", r.Status, r.Reason, r.ExitCode)
+	}
+
+	// span tree
+	var spans = make(map[string]InMemorySpan)
+	for _, span := range tp.Spans() {
+		if _, ok := spans[span.Name]; ok {
+			t.Fatalf("span(%s) is ended twice", span.Name)
+		}
+		spans[span.Name] = span
+	}
+	var root, ok = spans[SPAN_RUN]
+	if !ok || root.ParentID != 0 {
+		t.Fatalf("span(%s) is not a root span", SPAN_RUN)
+	}
+	for _, name := range []string{SPAN_SETUP_NAMESPACES, SPAN_EXEC, SPAN_SET_RLIMITS, SPAN_BUILD_FSFILTER, SPAN_PTRACE_LOOP, SPAN_TEARDOWN} {
+		if span, ok := spans[name]; !ok || span.ParentID != root.ID {
+			t.Errorf("span(%s) is not a child of span(%s)", name, SPAN_RUN)
+		}
+	}
+	if len(spans) != 7 {
+		t.Errorf("spans: %d, want 7", len(spans))
+	}
+
+	// events
+	var events = make(map[string][]InMemorySpanEvent)
+	for _, event := range spans[SPAN_PTRACE_LOOP].Events {
+		events[event.Name] = append(events[event.Name], event)
+	}
+	if len(events[SPAN_EVENT_NEW_CHILD]) != 1 {
+		t.Errorf("event(%s): %d, want 1", SPAN_EVENT_NEW_CHILD, len(events[SPAN_EVENT_NEW_CHILD]))
+	}
+	if len(events[SPAN_EVENT_VIOLATION]) != 1 {
+		t.Fatalf("event(%s): %d, want 1", SPAN_EVENT_VIOLATION, len(events[SPAN_EVENT_VIOLATION]))
+	}
+	for _, attr := range events[SPAN_EVENT_VIOLATION][0].Attributes {
+		if attr.Key == "syscall" && attr.Value != "openat" {
+			t.Errorf("event(%s): syscall(%v), want openat", SPAN_EVENT_VIOLATION, attr.Value)
+		}
+	}
+}
@@ -17,15 +17,17 @@ func _() {
 	_ = x[StatusViolation-6]
 	_ = x[StatusSignaled-7]
 	_ = x[StatusExitFailure-8]
+	_ = x[StatusSyscallLimitExceeded-9]
 }
 
-const _Status_name = "unsetoksandbox exec failuretime limit execeededmemory limit exceededoutput limit exceededsyscall violationterminated with a signalexit with nonzero code"
+const _Status_name = "unsetoksandbox exec failuretime limit execeededmemory limit exceededoutput limit exceededsyscall violationterminated with a signalexit with nonzero codesyscall limit exceeded"
 
-var _Status_index = [...]uint8{0, 5, 7, 27, 47, 68, 89, 106, 130, 152}
+var _Status_index = [...]uint8{0, 5, 7, 27, 47, 68, 89, 106, 130, 152, 174}
 
 func (i Status) String() string {
-	if i < 0 || i >= Status(len(_Status_index)-1) {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Status_index)-1 {
 		return "Status(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
-	return _Status_name[_Status_index[i]:_Status_index[i+1]]
+	return _Status_name[_Status_index[idx]:_Status_index[idx+1]]
 }
@@ -0,0 +1,17 @@
+package gsandbox
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStatusString(t *testing.T) {
+	for s := StatusUnset; s <= StatusSyscallLimitExceeded; s++ {
+		if name := s.String(); name == "" || strings.HasPrefix(name, "Status(") {
+			t.Errorf("Status(%d).String() = %q", int(s), name)
+		}
+	}
+	if name := StatusSyscallLimitExceeded.String(); name != "syscall limit exceeded" {
+		t.Errorf("StatusSyscallLimitExceeded.String() = %q", name)
+	}
+}
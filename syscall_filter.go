@@ -0,0 +1,128 @@
+package gsandbox
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/souk4711/gsandbox/pkg/ptrace"
+)
+
+// syscall arg comparison operators
+const (
+	ARG_OP_EQ        = "eq"        // arg == value
+	ARG_OP_NE        = "ne"        // arg != value
+	ARG_OP_LT        = "lt"        // arg < value
+	ARG_OP_LE        = "le"        // arg <= value
+	ARG_OP_GT        = "gt"        // arg > value
+	ARG_OP_GE        = "ge"        // arg >= value
+	ARG_OP_IN        = "in"        // arg is any of values
+	ARG_OP_NOT_IN    = "not-in"    // arg is none of values
+	ARG_OP_MASKED_EQ = "masked-eq" // arg & mask == value
+)
+
+// SyscallArgFilter compares the value of a syscall arg, like the argument comparison of seccomp(2). The value is
+// truncated to the width of the param as the kernel does, e.g. 32 bits for an int, plz see ptrace.ParamType#Width
+type SyscallArgFilter struct {
+	Index  int      // position of the arg, 0 ~ 5
+	Op     string   // comparison operator, ARG_OP_*
+	Values []uint64 // one value, or any number of values for ARG_OP_IN, ARG_OP_NOT_IN
+	Mask   uint64   // mask for ARG_OP_MASKED_EQ
+	Width  int      // bits of the param, 0 means 64
+	Signed bool     // the param is signed, ARG_OP_LT, ARG_OP_LE, ARG_OP_GT and ARG_OP_GE compare signed values
+}
+
+func (f *SyscallArgFilter) Match(v uint64) bool {
+	v &= syscallArgMask(f.Width)
+	switch f.Op {
+	case ARG_OP_EQ:
+		return v == f.Values[0]
+	case ARG_OP_NE:
+		return v != f.Values[0]
+	case ARG_OP_LT:
+		return f.compare(v, f.Values[0]) < 0
+	case ARG_OP_LE:
+		return f.compare(v, f.Values[0]) <= 0
+	case ARG_OP_GT:
+		return f.compare(v, f.Values[0]) > 0
+	case ARG_OP_GE:
+		return f.compare(v, f.Values[0]) >= 0
+	case ARG_OP_IN, ARG_OP_NOT_IN:
+		for _, value := range f.Values {
+			if v == value {
+				return f.Op == ARG_OP_IN
+			}
+		}
+		return f.Op == ARG_OP_NOT_IN
+	case ARG_OP_MASKED_EQ:
+		return v&f.Mask == f.Values[0]
+	default:
+		return false
+	}
+}
+
+// compare returns -1, 0 or +1 if a is less than, equal to, or greater than b, both are truncated to the width
+func (f *SyscallArgFilter) compare(a uint64, b uint64) int {
+	if f.Signed && f.Width > 0 && f.Width < 64 { // sign-extend
+		var shift = uint(64 - f.Width)
+		a, b = uint64(int64(a<<shift)>>shift), uint64(int64(b<<shift)>>shift)
+	}
+	if f.Signed {
+		switch {
+		case int64(a) < int64(b):
+			return -1
+		case int64(a) > int64(b):
+			return 1
+		}
+		return 0
+	}
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// syscallArgMask returns the mask of the width, 0 means 64
+func syscallArgMask(width int) uint64 {
+	if width <= 0 || width >= 64 {
+		return ^uint64(0)
+	}
+	return 1<<uint(width) - 1
+}
+
+// matchSyscallArgFilters checks the syscall matches all of the filters
+func matchSyscallArgFilters(curr *ptrace.Syscall, filters []SyscallArgFilter) bool {
+	for i := range filters {
+		if !filters[i].Match(curr.GetArgValue(filters[i].Index)) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseSyscallArgValue parses a number, e.g. 0, -1, 0x5401, or a constant name, e.g. TCGETS, SIGKILL, at the width
+// of the param, e.g. -1 is 0xffffffff for an int
+func parseSyscallArgValue(str string, width int) (uint64, error) {
+	var mask = syscallArgMask(width)
+	if v, err := strconv.ParseInt(str, 0, 64); err == nil && v < 0 {
+		if mask != ^uint64(0) && uint64(-v) > mask/2+1 {
+			return 0, fmt.Errorf("invalid value(%s): out of range", str)
+		}
+		return uint64(v) & mask, nil
+	}
+
+	var v uint64
+	if n, err := strconv.ParseUint(str, 0, 64); err == nil {
+		v = n
+	} else if n, ok := ptrace.LookupConstant(str); ok {
+		v = n
+	} else {
+		return 0, fmt.Errorf("invalid value(%s)", str)
+	}
+	if v&^mask != 0 {
+		return 0, fmt.Errorf("invalid value(%s): out of range", str)
+	}
+	return v, nil
+}
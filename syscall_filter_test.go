@@ -0,0 +1,117 @@
+package gsandbox
+
+import (
+	"testing"
+)
+
+func TestSyscallArgFilterTruncated(t *testing.T) {
+	var tests = []struct {
+		syscall PolicySyscall
+		matched []uint64
+		denied  []uint64
+	}{
+		{ // int pid_t, the kernel runs kill(-1, ...) for 0x1ffffffff
+			PolicySyscall{Name: "kill", Args: []PolicySyscallArg{{Index: 0, Op: ARG_OP_GT, Value: "0"}}},
+			[]uint64{1, 0x100000001},
+			[]uint64{0, 0xffffffff, 0x1ffffffff, 0xffffffffffffffff},
+		},
+		{ // -1 is parsed at 32 bits, so the zero-extended compat value matches too
+			PolicySyscall{Name: "kill", Args: []PolicySyscallArg{{Index: 0, Op: ARG_OP_NE, Value: "-1"}}},
+			[]uint64{0, 1},
+			[]uint64{0xffffffff, 0x1ffffffff, 0xffffffffffffffff},
+		},
+		{ // unsigned int, compared unsigned
+			PolicySyscall{Name: "fchownat", Args: []PolicySyscallArg{{Index: 2, Op: ARG_OP_NOT_IN, Values: []string{"0", "-1"}}}},
+			[]uint64{1000, 0x1000003e8},
+			[]uint64{0, 0x100000000, 0xffffffff},
+		},
+		{ // unsigned int request
+			PolicySyscall{Name: "ioctl", Args: []PolicySyscallArg{{Index: 1, Op: ARG_OP_MASKED_EQ, Mask: "0xffff", Value: "TCGETS"}}},
+			[]uint64{0x5401, 0xffff00005401},
+			[]uint64{0x5402},
+		},
+		{ // off_t, not truncated
+			PolicySyscall{Name: "lseek", Args: []PolicySyscallArg{{Index: 1, Op: ARG_OP_LT, Value: "4096"}}},
+			[]uint64{0, 4095},
+			[]uint64{4096, 0x100000000},
+		},
+	}
+	for _, tt := range tests {
+		filters, err := tt.syscall.toArgFilters()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range tt.matched {
+			if !filters[0].Match(v) {
+				t.Errorf("%s: %#x is denied", tt.syscall.Name, v)
+			}
+		}
+		for _, v := range tt.denied {
+			if filters[0].Match(v) {
+				t.Errorf("%s: %#x is matched", tt.syscall.Name, v)
+			}
+		}
+	}
+}
+
+func TestParseSyscallArgValue(t *testing.T) {
+	var tests = []struct {
+		str   string
+		width int
+		want  uint64
+		ok    bool
+	}{
+		{"-1", 64, 0xffffffffffffffff, true},
+		{"-1", 32, 0xffffffff, true},
+		{"-2147483648", 32, 0x80000000, true},
+		{"-2147483649", 32, 0, false},
+		{"0xffffffff", 32, 0xffffffff, true},
+		{"0x100000000", 32, 0, false},
+		{"0o644", 16, 0o644, true},
+		{"TCGETS", 32, 0x5401, true},
+		{"NOT_A_CONSTANT", 32, 0, false},
+	}
+	for _, tt := range tests {
+		v, err := parseSyscallArgValue(tt.str, tt.width)
+		if (err == nil) != tt.ok || v != tt.want {
+			t.Errorf("parseSyscallArgValue(%s, %d) = %#x, %v", tt.str, tt.width, v, err)
+		}
+	}
+}
+
+func TestExpandPolicySyscalls(t *testing.T) {
+	var kill = PolicySyscall{Name: "kill", Args: []PolicySyscallArg{{Index: 0, Op: ARG_OP_EQ, Value: "0"}}}
+	expanded, err := expandPolicySyscalls([]PolicySyscall{{Name: "@basic-io"}, {Name: "!lseek"}, {Name: "@process"}, {Name: "!kill"}, kill})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names = make(map[string]int)
+	for _, syscall := range expanded {
+		names[syscall.Name] += 1
+		if syscall.Name == "kill" && len(syscall.Args) == 0 {
+			t.Error("kill is allowed without the args")
+		}
+	}
+	for _, name := range []string{"read", "write", "execve"} {
+		if names[name] != 1 {
+			t.Errorf("%s: %d, want 1", name, names[name])
+		}
+	}
+	if names["lseek"] != 0 {
+		t.Error("lseek is not removed")
+	}
+	if names["kill"] != 1 {
+		t.Errorf("kill: %d, want 1", names["kill"])
+	}
+
+	if _, err := expandPolicySyscalls([]PolicySyscall{{Name: "!@process"}}); err != nil {
+		t.Error(err)
+	}
+	if _, err := expandPolicySyscalls([]PolicySyscall{{Name: "!kill", Args: kill.Args}}); err == nil {
+		t.Error("negation restricts the args")
+	}
+	if _, err := expandPolicySyscalls([]PolicySyscall{{Name: "@not-a-group"}}); err == nil {
+		t.Error("unknown group is expanded")
+	}
+}
@@ -0,0 +1,155 @@
+package gsandbox
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SyscallGroups holds the named syscall groups which can be used in the syscall whitelist, similar to
+// systemd's SystemCallFilter sets. A group may include other groups.
+var SyscallGroups = map[string][]string{
+	// basic IO: read, write, seek, close
+	"@basic-io": {
+		"close", "close_range", "dup", "dup2", "dup3", "lseek", "pread64", "preadv", "preadv2",
+		"pwrite64", "pwritev", "pwritev2", "read", "readv", "write", "writev",
+	},
+	// required by almost every program, e.g. by the dynamic linker and libc
+	"@default": {
+		"arch_prctl", "brk", "exit", "exit_group", "futex", "get_robust_list", "getegid", "geteuid",
+		"getgid", "getgroups", "getpid", "getppid", "getrandom", "getresgid", "getresuid", "gettid",
+		"getuid", "membarrier", "restart_syscall", "rseq", "rt_sigreturn", "sched_getaffinity",
+		"sched_yield", "set_robust_list", "set_tid_address", "uname",
+	},
+	// file system operations: open, stat, create, remove, rename, link, xattr
+	"@file-system": {
+		"access", "chdir", "chmod", "creat", "faccessat", "faccessat2", "fallocate", "fchdir",
+		"fchmod", "fchmodat", "fcntl", "fgetxattr", "flistxattr", "flock", "fremovexattr", "fsetxattr",
+		"fstat", "fstatfs", "fsync", "fdatasync", "ftruncate", "futimesat", "getcwd", "getdents",
+		"getdents64", "getxattr", "inotify_add_watch", "inotify_init", "inotify_init1", "inotify_rm_watch",
+		"lgetxattr", "link", "linkat", "listxattr", "llistxattr", "lremovexattr", "lsetxattr", "lstat",
+		"mkdir", "mkdirat", "mknod", "mknodat", "newfstatat", "open", "openat", "openat2", "readlink",
+		"readlinkat", "removexattr", "rename", "renameat", "renameat2", "rmdir", "setxattr", "stat",
+		"statfs", "statx", "symlink", "symlinkat", "truncate", "umask", "unlink", "unlinkat", "utime",
+		"utimensat", "utimes",
+	},
+	// event loops: poll, select, epoll, eventfd
+	"@io-event": {
+		"epoll_create", "epoll_create1", "epoll_ctl", "epoll_pwait", "epoll_pwait2", "epoll_wait",
+		"eventfd", "eventfd2", "poll", "ppoll", "pselect6", "select",
+	},
+	// inter-process communication: pipes, SysV IPC, POSIX message queues
+	"@ipc": {
+		"mq_getsetattr", "mq_notify", "mq_open", "mq_timedreceive", "mq_timedsend", "mq_unlink",
+		"msgctl", "msgget", "msgrcv", "msgsnd", "pipe", "pipe2", "semctl", "semget", "semop",
+		"semtimedop", "shmat", "shmctl", "shmdt", "shmget",
+	},
+	// memory management: mmap, mprotect, madvise, mlock
+	"@memory": {
+		"brk", "madvise", "memfd_create", "mincore", "mlock", "mlock2", "mlockall", "mmap", "mprotect",
+		"mremap", "msync", "munlock", "munlockall", "munmap", "pkey_alloc", "pkey_free", "pkey_mprotect",
+	},
+	// sockets
+	"@network": {
+		"accept", "accept4", "bind", "connect", "getpeername", "getsockname", "getsockopt", "listen",
+		"recvfrom", "recvmmsg", "recvmsg", "sendfile", "sendmmsg", "sendmsg", "sendto", "setsockopt",
+		"shutdown", "socket", "socketpair",
+	},
+	// process management: create, exec, wait, kill
+	"@process": {
+		"capget", "clone", "clone3", "execve", "execveat", "fork", "getpgid", "getpgrp", "getrusage",
+		"getsid", "kill", "pidfd_open", "pidfd_send_signal", "prctl", "rt_sigqueueinfo",
+		"rt_tgsigqueueinfo", "setpgid", "setsid", "tgkill", "tkill", "vfork", "wait4", "waitid",
+	},
+	// signal handling
+	"@signal": {
+		"rt_sigaction", "rt_sigpending", "rt_sigprocmask", "rt_sigreturn", "rt_sigsuspend",
+		"rt_sigtimedwait", "sigaltstack", "signalfd", "signalfd4",
+	},
+	// a typical command-line program or service
+	"@system-service": {
+		"@basic-io", "@default", "@file-system", "@io-event", "@ipc", "@memory", "@network", "@process",
+		"@signal", "@time",
+	},
+	// clocks and timers
+	"@time": {
+		"alarm", "clock_getres", "clock_gettime", "clock_nanosleep", "getitimer", "gettimeofday",
+		"nanosleep", "setitimer", "time", "timer_create", "timer_delete", "timer_getoverrun",
+		"timer_gettime", "timer_settime", "timerfd_create", "timerfd_gettime", "timerfd_settime", "times",
+	},
+}
+
+// expandSyscallGroup returns the syscall names in the group, including the ones of nested groups
+func expandSyscallGroup(group string) ([]string, error) {
+	var names = make([]string, 0)
+	var visited = make(map[string]struct{})
+
+	var expand func(group string) error
+	expand = func(group string) error {
+		if _, ok := visited[group]; ok {
+			return nil
+		}
+		visited[group] = struct{}{}
+
+		members, ok := SyscallGroups[group]
+		if !ok {
+			return fmt.Errorf("invalid syscall group(%s)", group)
+		}
+		for _, name := range members {
+			if strings.HasPrefix(name, "@") {
+				if err := expand(name); err != nil {
+					return err
+				}
+			} else {
+				names = append(names, name)
+			}
+		}
+		return nil
+	}
+
+	if err := expand(group); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// expandPolicySyscalls expands groups, e.g. @basic-io, and removes the negated ones, e.g. !ptrace, !@network.
+// A negation does not remove the entries which restrict the args, so `!kill` and `{name: kill, args: ...}`
+// allow kill(2) with the specified args only.
+func expandPolicySyscalls(syscalls []PolicySyscall) ([]PolicySyscall, error) {
+	var entries = make([]PolicySyscall, 0, len(syscalls))
+	var negated = make(map[string]struct{})
+	for _, syscall := range syscalls {
+		var name = strings.TrimPrefix(syscall.Name, "!")
+		var names = []string{name}
+		if strings.HasPrefix(name, "@") {
+			if v, err := expandSyscallGroup(name); err != nil {
+				return nil, err
+			} else {
+				names = v
+			}
+		}
+
+		// negation
+		if strings.HasPrefix(syscall.Name, "!") {
+			if len(syscall.Args) != 0 {
+				return nil, fmt.Errorf("invalid syscall args(%s): negation can not restrict the args", syscall.Name)
+			}
+			for _, name := range names {
+				negated[name] = struct{}{}
+			}
+			continue
+		}
+
+		for _, name := range names {
+			entries = append(entries, PolicySyscall{Name: name, Args: syscall.Args})
+		}
+	}
+
+	var expanded = make([]PolicySyscall, 0, len(entries))
+	for _, entry := range entries {
+		if _, ok := negated[entry.Name]; !ok || len(entry.Args) != 0 {
+			expanded = append(expanded, entry)
+		}
+	}
+	return expanded, nil
+}
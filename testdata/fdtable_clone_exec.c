@@ -0,0 +1,31 @@
+// The O_CLOEXEC fd shared with a CLONE_FILES child is still read by the parent after the child execs, execve(2)
+// unshares the fd table before closing the O_CLOEXEC fds.
+#define _GNU_SOURCE
+#include <fcntl.h>
+#include <sched.h>
+#include <signal.h>
+#include <sys/syscall.h>
+#include <sys/wait.h>
+#include <unistd.h>
+
+int main(void) {
+  int fd = open("/etc/hostname", O_RDONLY | O_CLOEXEC);
+  char buf[16];
+  pid_t pid;
+
+  if (fd < 0) {
+    return 1;
+  }
+  if ((pid = syscall(SYS_clone, CLONE_FILES | SIGCHLD, 0, 0, 0, 0)) == 0) {
+    char *argv[] = {"true", NULL};
+    execv("/usr/bin/true", argv);
+    _exit(127);
+  }
+  if (pid < 0 || waitpid(pid, NULL, 0) != pid) {
+    return 2;
+  }
+  if (read(fd, buf, sizeof(buf)) < 0) {
+    return 1;
+  }
+  return 0;
+}
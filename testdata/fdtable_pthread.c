@@ -0,0 +1,24 @@
+// The fd opened by a thread is read by the main thread, they share the fd table.
+#include <fcntl.h>
+#include <pthread.h>
+#include <unistd.h>
+
+static int fd = -1;
+
+static void *worker(void *arg) {
+  fd = open("/etc/hostname", O_RDONLY);
+  return NULL;
+}
+
+int main(void) {
+  pthread_t thread;
+  char buf[16];
+
+  if (pthread_create(&thread, NULL, worker, NULL) != 0 || pthread_join(thread, NULL) != 0) {
+    return 2;
+  }
+  if (fd < 0 || read(fd, buf, sizeof(buf)) < 0) {
+    return 1;
+  }
+  return 0;
+}
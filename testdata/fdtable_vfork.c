@@ -0,0 +1,25 @@
+// The fd closed by a vfork child is still read by the parent, the child has a copy of the fd table.
+#include <fcntl.h>
+#include <sys/wait.h>
+#include <unistd.h>
+
+int main(void) {
+  int fd = open("/etc/hostname", O_RDONLY);
+  char buf[16];
+  pid_t pid;
+
+  if (fd < 0) {
+    return 1;
+  }
+  if ((pid = vfork()) == 0) {
+    close(fd);
+    _exit(0);
+  }
+  if (pid < 0 || waitpid(pid, NULL, 0) != pid) {
+    return 2;
+  }
+  if (read(fd, buf, sizeof(buf)) < 0) {
+    return 1;
+  }
+  return 0;
+}
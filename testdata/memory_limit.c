@@ -0,0 +1,14 @@
+// Allocates until malloc(3) fails, then crashes on the NULL, or exits 0 with "recover".
+#include <stdlib.h>
+#include <string.h>
+
+int main(int argc, char *argv[]) {
+  for (;;) {
+    char *p = malloc(16 << 20);
+    if (p == NULL) {
+      if (argc > 1 && strcmp(argv[1], "recover") == 0) return 0;
+      *(volatile char *)p = 1;
+    }
+    memset(p, 1, 16 << 20);
+  }
+}
@@ -0,0 +1,5 @@
+// Writes to NULL, killed by SIGSEGV.
+int main(void) {
+  *(volatile char *)0 = 1;
+  return 0;
+}
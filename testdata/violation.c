@@ -0,0 +1,19 @@
+// A child is forked and reaped, then the parent opens a file for writing, which is not allowed by the policy.
+#include <fcntl.h>
+#include <sys/wait.h>
+#include <unistd.h>
+
+int main(void) {
+  pid_t pid;
+
+  if ((pid = fork()) == 0) {
+    _exit(0);
+  }
+  if (pid < 0 || waitpid(pid, NULL, 0) != pid) {
+    return 2;
+  }
+  if (open("/etc/hostname", O_WRONLY) < 0) {
+    return 1;
+  }
+  return 0;
+}
@@ -0,0 +1,158 @@
+package gsandbox
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/souk4711/gsandbox/pkg/ptrace"
+)
+
+// trace formats
+const (
+	TRACE_FORMAT_JSON   = "json"   // JSON Lines, one TraceEvent per line
+	TRACE_FORMAT_STRACE = "strace" // like `strace -f -tt -T`
+)
+
+// trace decisions
+const (
+	TRACE_DECISION_ALLOW = "allow"
+	TRACE_DECISION_DENY  = "deny"
+)
+
+// TraceEvent is a syscall invoked by a tracee, written as a line to the trace file
+type TraceEvent struct {
+	Time     time.Time     `json:"time"`             // time of the syscall-enter-stop
+	Pid      int           `json:"pid"`              // thread id of the caller
+	Arch     string        `json:"arch,omitempty"`   // ABI of the syscall if it's a compat one, e.g. i386
+	Syscall  string        `json:"syscall"`          // syscall name
+	Args     []string      `json:"args"`             // decoded args
+	Retval   *int          `json:"retval"`           // nil if the syscall never returns, e.g. exit(2), or is denied
+	Errno    string        `json:"errno,omitempty"`  // errno name if the syscall fails, e.g. ENOENT
+	Duration time.Duration `json:"duration"`         // time from syscall-enter-stop to syscall-exit-stop
+	Decision string        `json:"decision"`         // policy decision, allow or deny
+	Reason   string        `json:"reason,omitempty"` // why the syscall is denied
+
+	// used by the strace format
+	errnoDesc string // errno name and description, e.g. ENOENT (No such file or directory)
+	restart   bool   // the syscall is interrupted by a signal and will be restarted
+}
+
+type tracer struct {
+	w      *bufio.Writer
+	format string
+	events map[int]*TraceEvent // pid => syscall in progress
+}
+
+func newTracer(w io.Writer, format string) *tracer {
+	return &tracer{w: bufio.NewWriter(w), format: format, events: make(map[int]*TraceEvent)}
+}
+
+func (t *tracer) enter(pid int, curr *ptrace.Syscall, continued bool, reason string) {
+	var ev = &TraceEvent{Time: time.Now(), Pid: pid, Syscall: curr.GetName(), Decision: TRACE_DECISION_ALLOW}
+	if curr.IsCompat() {
+		ev.Arch = curr.GetArch()
+	}
+	ev.Args = make([]string, len(curr.GetArgs()))
+	for i, arg := range curr.GetArgs() {
+		ev.Args[i] = arg.String()
+	}
+
+	// the tracee is killed once a syscall is denied
+	if !continued {
+		ev.Decision = TRACE_DECISION_DENY
+		ev.Reason = reason
+		t.write(ev)
+		return
+	}
+
+	t.flush(pid)
+	t.events[pid] = ev
+}
+
+func (t *tracer) leave(pid int, retval *ptrace.SyscallRetval) {
+	var ev, ok = t.events[pid]
+	if !ok {
+		return
+	}
+	delete(t.events, pid)
+
+	ev.Duration = time.Since(ev.Time)
+	if retval != nil {
+		var value = retval.GetValue()
+		ev.Retval = &value
+		if retval.HasError() {
+			ev.Errno = retval.GetErrnoName()
+			ev.errnoDesc = retval.GetErrnoDesc()
+			ev.restart = retval.HasError_ERESTART()
+		}
+	}
+	t.write(ev)
+}
+
+// exec moves the syscall in progress when a non-leader thread execs
+func (t *tracer) exec(pid int, formerPid int) {
+	if ev, ok := t.events[formerPid]; ok {
+		delete(t.events, formerPid)
+		ev.Pid = pid
+		t.events[pid] = ev
+	}
+}
+
+// flush writes the syscall in progress, which never returns, e.g. exit(2)
+func (t *tracer) flush(pid int) {
+	if ev, ok := t.events[pid]; ok {
+		delete(t.events, pid)
+		t.write(ev)
+	}
+}
+
+func (t *tracer) finish() {
+	var pids = make([]int, 0, len(t.events))
+	for pid := range t.events {
+		pids = append(pids, pid)
+	}
+	sort.Ints(pids)
+	for _, pid := range pids {
+		t.flush(pid)
+	}
+	_ = t.w.Flush()
+}
+
+func (t *tracer) write(ev *TraceEvent) {
+	switch t.format {
+	case TRACE_FORMAT_STRACE:
+		_, _ = t.w.WriteString(ev.straceString() + "\n")
+	default:
+		var data, _ = json.Marshal(ev)
+		_, _ = t.w.Write(append(data, '\n'))
+	}
+}
+
+// straceString formats the event like `strace -f -tt -T`, e.g.
+//
+//	1234  12:34:56.123456 openat(AT_FDCWD, "/etc/passwd", O_RDONLY) = -1 ENOENT (No such file or directory) <0.000012>
+func (ev *TraceEvent) straceString() string {
+	var name = ev.Syscall
+	if ev.Arch != "" {
+		name = ev.Arch + ":" + name
+	}
+
+	var prefix = fmt.Sprintf("%-5d %s %s(%s) = ", ev.Pid, ev.Time.Format("15:04:05.000000"), name, strings.Join(ev.Args, ", "))
+	switch {
+	case ev.Decision == TRACE_DECISION_DENY:
+		return prefix + fmt.Sprintf("? DENIED (%s)", ev.Reason)
+	case ev.Retval == nil:
+		return prefix + "?"
+	case ev.restart:
+		return prefix + fmt.Sprintf("? %s <%.6f>", ev.errnoDesc, ev.Duration.Seconds())
+	case ev.Errno != "":
+		return prefix + fmt.Sprintf("-1 %s <%.6f>", ev.errnoDesc, ev.Duration.Seconds())
+	default:
+		return prefix + fmt.Sprintf("%d <%.6f>", *ev.Retval, ev.Duration.Seconds())
+	}
+}